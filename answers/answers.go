@@ -0,0 +1,120 @@
+// Package answers keeps the accepted answer for each day and part, keyed by
+// the input it was computed from, so solutions can be checked after a
+// refactor. An answer only recorded from a solver's result, and never
+// accepted by the server, is marked as such.
+package answers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Answer is the accepted answer for a day and part of one input, or with
+// Recorded, a solver's result that hasn't been submitted.
+type Answer struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	InputSHA256 string `json:"input_sha256"`
+	Answer      string `json:"answer"`
+	Recorded    bool   `json:"recorded,omitempty"`
+}
+
+// Store is every Answer, saved as JSON at Path.
+type Store struct {
+	Path    string
+	Answers []Answer
+}
+
+// Hash returns the key an input is stored under.
+func Hash(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// Load reads the Store at path. A missing file is an empty Store.
+func Load(path string) (*Store, error) {
+	store := &Store{Path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &store.Answers); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Save writes the Store back to its Path, sorted by day and part.
+func (s *Store) Save() error {
+	sort.SliceStable(s.Answers, func(i, j int) bool {
+		a, b := s.Answers[i], s.Answers[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		return a.Part < b.Part
+	})
+
+	data, err := json.MarshalIndent(s.Answers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, append(data, '\n'), 0o644)
+}
+
+// Lookup returns the answer, accepted or recorded, for a day and part of the
+// input with the given hash.
+func (s *Store) Lookup(day int, part int, inputSHA256 string) (Answer, bool) {
+	for _, answer := range s.Answers {
+		if answer.Day == day && answer.Part == part && answer.InputSHA256 == inputSHA256 {
+			return answer, true
+		}
+	}
+	return Answer{}, false
+}
+
+// Has reports whether a day and part has an accepted answer for any input.
+func (s *Store) Has(day int, part int) bool {
+	for _, answer := range s.Answers {
+		if answer.Day == day && answer.Part == part && !answer.Recorded {
+			return true
+		}
+	}
+	return false
+}
+
+// Set stores the accepted answer for a day and part of the input with the
+// given hash, replacing any answer already stored for it.
+func (s *Store) Set(day int, part int, inputSHA256 string, answer string) {
+	s.set(Answer{Day: day, Part: part, InputSHA256: inputSHA256, Answer: answer})
+}
+
+// Record stores a solver's result for a day and part of the input with the
+// given hash, marked as not accepted, unless an answer is already stored for
+// it.
+func (s *Store) Record(day int, part int, inputSHA256 string, answer string) {
+	if _, found := s.Lookup(day, part, inputSHA256); !found {
+		s.set(Answer{Day: day, Part: part, InputSHA256: inputSHA256, Answer: answer, Recorded: true})
+	}
+}
+
+// set stores answer, replacing any answer already stored for its day, part
+// and input.
+func (s *Store) set(answer Answer) {
+	for i, a := range s.Answers {
+		if a.Day == answer.Day && a.Part == answer.Part && a.InputSHA256 == answer.InputSHA256 {
+			s.Answers[i] = answer
+			return
+		}
+	}
+	s.Answers = append(s.Answers, answer)
+}
@@ -0,0 +1,74 @@
+package answers
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	expected := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got := Hash(nil); got != expected {
+		t.Fatalf("Expected %s, but got %s", expected, got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store.Set(7, 2, "b", "5905")
+	store.Set(7, 1, "b", "6440")
+	store.Set(6, 1, "a", "288")
+	store.Set(7, 1, "b", "6441") // Replaces the first answer.
+	store.Record(6, 2, "a", "71503")
+	store.Record(6, 1, "a", "289") // Keeps the accepted answer.
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Answer{
+		{6, 1, "a", "288", false},
+		{6, 2, "a", "71503", true},
+		{7, 1, "b", "6441", false},
+		{7, 2, "b", "5905", false},
+	}
+	if !reflect.DeepEqual(got.Answers, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, got.Answers)
+	}
+
+	testCases := []struct {
+		day      int
+		part     int
+		hash     string
+		expected string
+		found    bool
+	}{
+		{7, 1, "b", "6441", true},
+		{7, 1, "a", "", false},
+		{5, 1, "b", "", false},
+	}
+	for _, tc := range testCases {
+		answer, found := got.Lookup(tc.day, tc.part, tc.hash)
+		if answer.Answer != tc.expected || found != tc.found {
+			t.Fatalf("Expected %q %v, but got %q %v", tc.expected, tc.found, answer.Answer, found)
+		}
+	}
+
+	if !got.Has(7, 2) || got.Has(6, 2) || got.Has(5, 1) {
+		t.Fatalf("Expected day 7 part 2 to have an accepted answer and day 6 part 2, only recorded, and day 5 part 1 not to")
+	}
+
+	got.Set(6, 2, "a", "71503")
+	if answer, _ := got.Lookup(6, 2, "a"); answer.Recorded {
+		t.Fatalf("Expected an accepted answer to replace a recorded one")
+	}
+}
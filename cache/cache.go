@@ -0,0 +1,89 @@
+// Package cache keeps puzzle inputs under the user's cache directory, fetching
+// each from the server the first time it is needed.
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+)
+
+// ErrNotCached is returned for an input that isn't cached when there is no
+// Client to fetch it with.
+var ErrNotCached = errors.New("input not cached and no session to fetch it with")
+
+// Store is a directory of cached inputs, one file per day.
+type Store struct {
+	Dir    string
+	Client *remote.Client // Fetches missing inputs. May be nil.
+}
+
+// DefaultDir returns the directory inputs are cached in by default.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "advent-of-code-2023"), nil
+}
+
+// Path returns where the input for a day is cached.
+func (s *Store) Path(day int) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("day%d_input.txt", day))
+}
+
+// Open returns the cached input for a day, fetching and caching it first if
+// needed.
+func (s *Store) Open(day int) (io.ReadCloser, error) {
+	file, err := os.Open(s.Path(day))
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if s.Client == nil {
+		return nil, fmt.Errorf("day %d: %w", day, ErrNotCached)
+	}
+
+	data, err := s.Client.Input(day)
+	if err != nil {
+		return nil, fmt.Errorf("day %d: fetching input: %w", day, err)
+	}
+
+	if err := s.Save(day, data); err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Save caches the input for a day. The file is written to a temporary name
+// and renamed, so an interrupted write never leaves a partial input behind.
+func (s *Store) Save(day int, data []byte) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, fmt.Sprintf(".day%d-*", day))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path(day))
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+)
+
+func readAll(t *testing.T, store *Store, day int) string {
+	t.Helper()
+	reader, err := store.Open(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestOpenFetchesOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("32T3K 765\n"))
+	}))
+	defer server.Close()
+
+	store := &Store{Dir: t.TempDir(), Client: remote.NewClient(server.URL, "secret")}
+
+	for i := 0; i < 2; i++ {
+		if got := readAll(t, store, 7); got != "32T3K 765\n" {
+			t.Fatalf("Expected %q, but got %q", "32T3K 765\n", got)
+		}
+	}
+
+	if requests != 1 {
+		t.Fatalf("Expected %d request, but got %d", 1, requests)
+	}
+
+	if _, err := os.Stat(store.Path(7)); err != nil {
+		t.Fatalf("Expected the input to be cached: %v", err)
+	}
+}
+
+func TestOpenFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := &Store{Dir: t.TempDir(), Client: remote.NewClient(server.URL, "secret")}
+	if _, err := store.Open(25); err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	if _, err := os.Stat(store.Path(25)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected nothing to be cached, but got %v", err)
+	}
+}
+
+func TestOpenWithoutClient(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	if _, err := store.Open(1); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("Expected %v, but got %v", ErrNotCached, err)
+	}
+
+	if err := store.Save(1, []byte("1abc2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := readAll(t, store, 1); got != "1abc2\n" {
+		t.Fatalf("Expected %q, but got %q", "1abc2\n", got)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/bench"
+)
+
+// benchCmd benchmarks every day and part. Given one git revision it compares
+// that revision with the working tree; given two, it compares them.
+func benchCmd(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	pattern := flags.String("bench", ".", "run only the benchmarks whose day/part/input match this regexp, e.g. day3/part1")
+	count := flags.Int("count", 1, "run each benchmark this many times and average the results")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		_, err := bench.Run(".", *pattern, *count, os.Stdout)
+		return err
+	}
+
+	old, err := benchRevision(flags.Arg(0), *pattern, *count)
+	if err != nil {
+		return err
+	}
+
+	var new bench.Results
+	if flags.NArg() > 1 {
+		new, err = benchRevision(flags.Arg(1), *pattern, *count)
+	} else {
+		new, err = bench.Run(".", *pattern, *count, os.Stderr)
+	}
+	if err != nil {
+		return err
+	}
+
+	return bench.WriteTable(os.Stdout, old, new)
+}
+
+// benchRevision runs the benchmarks at a git revision.
+func benchRevision(rev string, pattern string, count int) (bench.Results, error) {
+	dir, cleanup, err := bench.Checkout(rev)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return bench.Run(dir, pattern, count, os.Stderr)
+}
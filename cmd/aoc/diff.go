@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// diffCmd checks every day and part that has an oracle, or just -day, against
+// it on small generated inputs, and prints the first input they disagree on.
+func diffCmd(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day to check (default: every day with an oracle)")
+	seeds := flags.Int("seeds", differential.Seeds, "how many generated inputs to check each part against")
+	flags.Parse(args)
+
+	puzzles := solver.Puzzles()
+	if *day != 0 {
+		puzzle, err := solver.Lookup(*day)
+		if err != nil {
+			return err
+		}
+		puzzles = []solver.Puzzle{puzzle}
+	}
+
+	for _, puzzle := range puzzles {
+		if puzzle.Oracle == nil {
+			continue
+		}
+		for _, part := range solver.Parts {
+			divergence, err := differential.Check(context.Background(), puzzle, part, *seeds)
+			switch {
+			case errors.Is(err, solver.ErrUnsolved):
+				fmt.Printf("day %d part %d: unsolved\n", puzzle.Day, part)
+			case err != nil:
+				return err
+			case divergence != nil:
+				return divergence
+			default:
+				fmt.Printf("day %d part %d: ok\n", puzzle.Day, part)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/examples"
+)
+
+// examplesCmd extracts the examples and their answers from a saved puzzle page
+// into a day's testdata directory.
+func examplesCmd(args []string) error {
+	flags := flag.NewFlagSet("examples", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day the page is for (1-25)")
+	dir := flags.String("dir", "", "where to write the examples (default: dayN/testdata)")
+	force := flags.Bool("force", false, "overwrite existing examples")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("expected the path of a saved puzzle page")
+	}
+	if *dir == "" {
+		if *day < 1 || *day > 25 {
+			return fmt.Errorf("day %d is not between 1 and 25", *day)
+		}
+		*dir = fmt.Sprintf("day%d/testdata", *day)
+	}
+
+	page, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	found, err := examples.Extract(string(page))
+	if err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+
+	paths, err := examples.Write(*dir, found, *force)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scottbarnes/advent-of-code-2023/inputcrypt"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+const inputsUsage = `Usage: aoc inputs <command> [arguments]
+
+Commands:
+  encrypt   encrypt each day's committed input to dayN_input.txt.enc
+  decrypt   decrypt each day's encrypted input to dayN_input.txt
+  rekey     encrypt each day's encrypted input again under a new key
+  keygen    print a new random key
+
+The key is read from $AOC_INPUT_KEY or the file named by $AOC_INPUT_KEY_FILE.
+An input kept encrypted is read from dayN_input.txt.enc, and a plaintext
+dayN_input.txt beside it must hold the same input.
+
+.gitignore ignores the plaintext inputs, so only the encrypted ones are
+committed. To commit a new day's input, with the key the others are
+encrypted under:
+
+  AOC_INPUT_KEY_FILE=~/aoc-input.key aoc inputs encrypt
+  git add day*/day*_input.txt.enc
+  git commit
+
+The commits from before the inputs were encrypted still hold them in plain
+text; removing them from history is a separate rewrite.
+`
+
+// inputsCmd encrypts and decrypts the inputs committed in the repository.
+func inputsCmd(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, inputsUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "encrypt":
+		return encryptInputsCmd(args[1:])
+	case "decrypt":
+		return decryptInputsCmd(args[1:])
+	case "rekey":
+		return rekeyInputsCmd(args[1:])
+	case "keygen":
+		key, err := inputcrypt.GenerateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Println(key)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], inputsUsage)
+	}
+}
+
+// encryptInputsCmd encrypts every day's committed input, and with -remove
+// deletes the plaintext once it is encrypted.
+func encryptInputsCmd(args []string) error {
+	flags := flag.NewFlagSet("inputs encrypt", flag.ExitOnError)
+	remove := flags.Bool("remove", false, "delete each plaintext input once it is encrypted")
+	flags.Parse(args)
+
+	key, err := inputcrypt.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	return eachInput(func(path string) (string, error) {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return "no plaintext input", nil
+		}
+		wrote, err := inputcrypt.EncryptFile(key, path)
+		if err != nil {
+			return "", err
+		}
+
+		result := "unchanged"
+		if wrote {
+			result = "encrypted"
+		}
+		if *remove {
+			if err := os.Remove(path); err != nil {
+				return "", err
+			}
+			result += ", plaintext removed"
+		}
+		return result, nil
+	})
+}
+
+// decryptInputsCmd decrypts every day's encrypted input next to it.
+func decryptInputsCmd(args []string) error {
+	flags := flag.NewFlagSet("inputs decrypt", flag.ExitOnError)
+	force := flags.Bool("force", false, "replace plaintext inputs that differ from their encrypted copy")
+	flags.Parse(args)
+
+	key, err := inputcrypt.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	return eachInput(func(path string) (string, error) {
+		if _, err := os.Stat(path + inputcrypt.Ext); errors.Is(err, os.ErrNotExist) {
+			return "no encrypted input", nil
+		}
+		wrote, err := inputcrypt.DecryptFile(key, path, *force)
+		if err != nil || !wrote {
+			return "unchanged", err
+		}
+		return "decrypted", nil
+	})
+}
+
+// rekeyInputsCmd encrypts every day's encrypted input again under the key in
+// -new-key-file. Once done, that key replaces the old one.
+func rekeyInputsCmd(args []string) error {
+	flags := flag.NewFlagSet("inputs rekey", flag.ExitOnError)
+	newKeyFile := flags.String("new-key-file", "", "the file holding the new key, e.g. written by aoc inputs keygen")
+	flags.Parse(args)
+
+	if *newKeyFile == "" {
+		return errors.New("-new-key-file is required")
+	}
+	oldKey, err := inputcrypt.LoadKey()
+	if err != nil {
+		return err
+	}
+	newKey, err := inputcrypt.ReadKeyFile(*newKeyFile)
+	if err != nil {
+		return err
+	}
+
+	// Check every input opens with the old key before changing any, so a
+	// wrong key doesn't leave the inputs under two keys.
+	for _, puzzle := range solver.Puzzles() {
+		path := cli.CommittedInput(puzzle.Day) + inputcrypt.Ext
+		sealed, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			_, err = inputcrypt.Open(oldKey, filepath.Base(cli.CommittedInput(puzzle.Day)), sealed)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return eachInput(func(path string) (string, error) {
+		if _, err := os.Stat(path + inputcrypt.Ext); errors.Is(err, os.ErrNotExist) {
+			return "no encrypted input", nil
+		}
+		return "rekeyed", inputcrypt.RekeyFile(oldKey, newKey, path)
+	})
+}
+
+// eachInput calls do with the committed input path of every registered day
+// and prints what it did, stopping at the first error.
+func eachInput(do func(path string) (string, error)) error {
+	for _, puzzle := range solver.Puzzles() {
+		path := cli.CommittedInput(puzzle.Day)
+		result, err := do(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("%s: %s\n", path, result)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// listCmd prints every registered day with its title and parts.
+func listCmd(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	flags.Parse(args)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tTITLE\tPARTS")
+	for _, puzzle := range solver.Puzzles() {
+		fmt.Fprintf(w, "%d\t%s\t%v\n", puzzle.Day, puzzle.Title, solver.Parts)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,140 @@
+// Command aoc runs any registered Advent of Code solution.
+//
+// Usage:
+//
+//	aoc run -day 5 -part 2 [-input path|-] [-format text|json]
+//	aoc run -all [-workers n] [-timeout 1m] [-format text|json]
+//	aoc list
+//	aoc status [-answers answers.json]
+//	aoc new -day 8 [-title "Haunted Wasteland"] [-example path]
+//	aoc examples -day 8 [-dir path] [-force] page.html
+//	aoc submit -day 5 -part 2 [-input path|-] [-answer value]
+//	aoc verify [-answers answers.json] [-record]
+//	aoc bench [-bench regexp] [-count n] [old-rev [new-rev]]
+//	aoc diff [-day 5] [-seeds n]
+//	aoc watch -day 5 [-input path] [-interval 500ms]
+//	aoc inputs encrypt [-remove] | decrypt [-force] | rekey -new-key-file path | keygen
+//
+// Without -input, inputs are read from the cache in $AOC_CACHE_DIR (default:
+// the user cache directory). A missing input is fetched from $AOC_BASE_URL
+// (default: https://adventofcode.com) with the session cookie in $AOC_SESSION.
+//
+// run solves each day with its registered solver and its defaults: day 1
+// part 2 counts English number words, in lower case, and a line without
+// digits is an error. Other languages or a table of words (-words,
+// -words-file), case folding (-fold-case) and another policy for lines
+// without digits (-no-digits) are options of the day1 command only.
+//
+// status draws a calendar of days 1-25 showing, for each part, whether it is
+// solved, passes its examples and has an accepted answer, and how long its
+// last run on the default input took.
+//
+// With -format json, run prints each result as a JSON object on its own line:
+// {"day", "part", "answer", "duration_ns", "input_sha256", "error"}.
+//
+// Submitted answers and their verdicts are logged in the cache directory. An
+// answer already rejected, or outside a known too high or too low bound, is
+// never submitted again. A correct answer is added to answers.json, which
+// verify checks every day and part against. verify -record adds the results
+// of parts without an answer there too, marked "recorded" as they were never
+// accepted; status counts only accepted answers. The answers committed so far
+// are all recorded.
+//
+// bench runs every day and part on its real input and its example. Given git
+// revisions, it benchmarks each in a temporary worktree and prints a table
+// comparing ns/op and allocs/op; one revision is compared with the working
+// tree. A revision from before the benchmarks were added is given them, in the
+// first form for solvers without a context; one from before solver.Puzzles,
+// such as the first commit, can't be benchmarked. At a revision without
+// testdata examples, only the real inputs are benchmarked.
+//
+// diff checks each day's solver against its oracle, a brute-force solution,
+// on small generated inputs and prints the first input they disagree on.
+//
+// watch polls a day's package, command and input for changes. On each it
+// rebuilds the day, reruns its example tests and solves its input, printing
+// each answer and its time against the previous run's.
+//
+// The inputs committed in the repository are kept encrypted, as
+// dayN/dayN_input.txt.enc, so that it can be published without them. Inputs
+// are decrypted wherever they are read with the key in $AOC_INPUT_KEY or the
+// file named by $AOC_INPUT_KEY_FILE, written by aoc inputs keygen. inputs
+// encrypt, decrypt and rekey convert every day's input. A plaintext input
+// beside its encrypted copy must hold the same input, or reading it fails.
+// .gitignore ignores the plaintext inputs, and aoc inputs with no arguments
+// prints the steps to commit a new one.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	_ "github.com/scottbarnes/advent-of-code-2023/days"
+)
+
+const usage = `Usage: aoc <command> [arguments]
+
+Commands:
+  run       run a day and part against an input file, or -all of them
+  list      list the registered days
+  status    show a calendar of every day's progress
+  new       create the package, test and command for a new day
+  examples  extract the examples and answers from a saved puzzle page
+  submit    solve a day and part and submit the answer
+  verify    check every day and part against its accepted answer
+  bench     benchmark every day and part, or compare two git revisions
+  diff      check every day and part against its brute-force oracle
+  watch     rebuild, test and rerun a day whenever its files change
+  inputs    encrypt or decrypt the committed inputs
+
+Environment:
+  AOC_SESSION         session cookie used to fetch inputs and submit answers
+  AOC_BASE_URL        server to fetch inputs from and submit answers to (default https://adventofcode.com)
+  AOC_CACHE_DIR       where inputs and submissions are kept (default: the user cache directory)
+  AOC_INPUT_KEY       key the committed inputs are encrypted with, as 64 hex digits
+  AOC_INPUT_KEY_FILE  file holding the key, if AOC_INPUT_KEY is unset
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(os.Args[2:])
+	case "list":
+		err = listCmd(os.Args[2:])
+	case "status":
+		err = statusCmd(os.Args[2:])
+	case "new":
+		err = newCmd(os.Args[2:])
+	case "examples":
+		err = examplesCmd(os.Args[2:])
+	case "submit":
+		err = submitCmd(os.Args[2:])
+	case "verify":
+		err = verifyCmd(os.Args[2:])
+	case "bench":
+		err = benchCmd(os.Args[2:])
+	case "diff":
+		err = diffCmd(os.Args[2:])
+	case "watch":
+		err = watchCmd(os.Args[2:])
+	case "inputs":
+		err = inputsCmd(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/scaffold"
+)
+
+// newCmd creates the package, test, example and command for a new day and
+// registers it in package days. It is run from the root of the repository.
+func newCmd(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day to create (1-25)")
+	title := flags.String("title", "", "the puzzle's title (default: Day N)")
+	examplePath := flags.String("example", "", "a file holding the example input (default: an empty example)")
+	flags.Parse(args)
+
+	var example []byte
+	if *examplePath != "" {
+		var err error
+		example, err = os.ReadFile(*examplePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	paths, err := scaffold.Create(".", scaffold.Day{Day: *day, Title: *title, Example: example})
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/internal/runner"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// runCmd runs a single day and part and prints the answer, or with -all runs
+// every day and part and prints a table of the results.
+func runCmd(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day to run (1-25)")
+	part := flags.Int("part", 1, "the puzzle part to run (1 or 2)")
+	inputPath := flags.String("input", "", "the input file, or - for stdin (default: the cached input)")
+	all := flags.Bool("all", false, "run every day and part on its default input")
+	workers := flags.Int("workers", runtime.NumCPU(), "with -all, how many parts to run at once")
+	timeout := flags.Duration("timeout", time.Minute, "with -all, how long each part may run (0 for no limit)")
+	format := flags.String("format", cli.FormatText, cli.FormatUsage)
+	flags.Parse(args)
+
+	if err := cli.CheckFormat(*format); err != nil {
+		return err
+	}
+
+	if *all {
+		return runAll(*format, *workers, *timeout)
+	}
+
+	puzzle, err := solver.Lookup(*day)
+	if err != nil {
+		return err
+	}
+	return cli.RunPart(*format, puzzle, *part, *inputPath)
+}
+
+// runAll runs every day and part and prints a table of the answers, how long
+// each took and any error, or one JSON object per line for each. Unsolved
+// parts are listed but don't fail the run.
+func runAll(format string, workers int, timeout time.Duration) error {
+	readInput := func(day int) ([]byte, error) { return cli.ReadInput(day, "") }
+	results := runner.RunAll(context.Background(), solver.Puzzles(), readInput, runner.Options{Workers: workers, Timeout: timeout})
+	if err := cli.RecordRuns(results...); err != nil {
+		return err
+	}
+
+	if format == cli.FormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DAY\tPART\tANSWER\tDURATION\tERROR")
+		for _, result := range results {
+			errText := ""
+			if result.Err != nil {
+				errText = result.Err.Error()
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%v\t%s\n", result.Day, result.Part, result.Answer, result.Duration.Round(time.Microsecond), errText)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, solver.ErrUnsolved) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/history"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/internal/status"
+)
+
+// statusCmd draws a calendar of every day of the event with, for each part,
+// whether it is solved, passes its examples and has an accepted answer, and
+// how long its last run took.
+func statusCmd(args []string) error {
+	flags := flag.NewFlagSet("status", flag.ExitOnError)
+	answersPath := flags.String("answers", "answers.json", "the accepted answers file")
+	flags.Parse(args)
+
+	store, err := answers.Load(*answersPath)
+	if err != nil {
+		return err
+	}
+	historyPath, err := cli.HistoryPath()
+	if err != nil {
+		return err
+	}
+	runs, err := history.Load(historyPath)
+	if err != nil {
+		return err
+	}
+
+	days := status.Collect(context.Background(), ".", store, runs)
+	return status.WriteCalendar(os.Stdout, days)
+}
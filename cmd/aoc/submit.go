@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+	"github.com/scottbarnes/advent-of-code-2023/submissions"
+)
+
+// submitCmd solves a day and part and submits the answer, unless the
+// submission log shows the server would reject it.
+func submitCmd(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day to submit (1-25)")
+	part := flags.Int("part", 1, "the puzzle part to submit (1 or 2)")
+	inputPath := flags.String("input", "", "the input file, or - for stdin (default: the cached input)")
+	answer := flags.String("answer", "", "the answer to submit (default: solve the puzzle)")
+	answersPath := flags.String("answers", "answers.json", "the accepted answers file to add a correct answer to")
+	flags.Parse(args)
+
+	session := os.Getenv("AOC_SESSION")
+	if session == "" {
+		return errors.New("set $AOC_SESSION to submit answers")
+	}
+
+	// Checked however the answer is found, as it is posted for day and part.
+	puzzle, err := solver.Lookup(*day)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(solver.Parts, *part) {
+		return fmt.Errorf("day %d has no part %d", *day, *part)
+	}
+
+	var input []byte
+	if *answer == "" {
+		input, err = cli.ReadInput(*day, *inputPath)
+		if err != nil {
+			return err
+		}
+		*answer, err = puzzle.Solve(context.Background(), *part, bytes.NewReader(input))
+		if err != nil {
+			return err
+		}
+	}
+
+	dir, err := cli.CacheDir()
+	if err != nil {
+		return err
+	}
+	log, err := submissions.Load(filepath.Join(dir, "submissions.json"))
+	if err != nil {
+		return err
+	}
+
+	if err := log.Check(*day, *part, *answer, time.Now()); err != nil {
+		return fmt.Errorf("not submitting %s: %w", *answer, err)
+	}
+
+	verdict, err := remote.NewClient(cli.BaseURL(), session).Submit(*day, *part, *answer)
+	if err != nil {
+		return err
+	}
+
+	log.Record(*day, *part, *answer, verdict, time.Now())
+	if err := log.Save(); err != nil {
+		return err
+	}
+
+	fmt.Println(verdict.Message)
+	if verdict.Outcome != remote.Correct {
+		return fmt.Errorf("answer %s: %v", *answer, verdict.Outcome)
+	}
+
+	// Only an answer computed here is known to belong to an input.
+	if input == nil {
+		return nil
+	}
+	store, err := answers.Load(*answersPath)
+	if err != nil {
+		return err
+	}
+	store.Set(*day, *part, answers.Hash(input), *answer)
+	return store.Save()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// verifyCmd reruns every registered day and part against its input and
+// compares each result with the accepted answer for that input, or the one
+// recorded by -record, which is only what the code gave when it was run.
+func verifyCmd(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	answersPath := flags.String("answers", "answers.json", "the accepted answers file")
+	record := flags.Bool("record", false, "store the results for parts that have no answer yet, marked as recorded, not accepted")
+	flags.Parse(args)
+
+	store, err := answers.Load(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tPART\tRESULT")
+	for _, puzzle := range solver.Puzzles() {
+		input, err := cli.ReadInput(puzzle.Day, "")
+		if err != nil {
+			fmt.Fprintf(w, "%d\t-\t%v\n", puzzle.Day, err)
+			failed++
+			continue
+		}
+		hash := answers.Hash(input)
+
+		for _, part := range solver.Parts {
+			got, err := puzzle.Solve(context.Background(), part, bytes.NewReader(input))
+			want, found := store.Lookup(puzzle.Day, part, hash)
+			switch {
+			case errors.Is(err, solver.ErrUnsolved):
+				fmt.Fprintf(w, "%d\t%d\tunsolved\n", puzzle.Day, part)
+			case err != nil:
+				fmt.Fprintf(w, "%d\t%d\terror: %v\n", puzzle.Day, part, err)
+				failed++
+			case !found && *record:
+				store.Record(puzzle.Day, part, hash, got)
+				fmt.Fprintf(w, "%d\t%d\trecorded %s\n", puzzle.Day, part, got)
+			case !found:
+				fmt.Fprintf(w, "%d\t%d\tno accepted answer (got %s)\n", puzzle.Day, part, got)
+			case got != want.Answer:
+				fmt.Fprintf(w, "%d\t%d\tMISMATCH: got %s, want %s%s\n", puzzle.Day, part, got, want.Answer, recordedNote(want))
+				failed++
+			default:
+				fmt.Fprintf(w, "%d\t%d\tok%s\n", puzzle.Day, part, recordedNote(want))
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if *record {
+		if err := store.Save(); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d failed", failed)
+	}
+	return nil
+}
+
+// recordedNote returns a note for an answer that was recorded, not accepted.
+func recordedNote(answer answers.Answer) string {
+	if answer.Recorded {
+		return " (recorded, not accepted)"
+	}
+	return ""
+}
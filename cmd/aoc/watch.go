@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/inputcrypt"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+	"github.com/scottbarnes/advent-of-code-2023/internal/runner"
+	"github.com/scottbarnes/advent-of-code-2023/internal/watch"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// watchCmd rebuilds a day, reruns its example tests and solves its input every
+// time one of its files or its input changes, until interrupted.
+func watchCmd(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	day := flags.Int("day", 0, "the day to watch")
+	inputPath := flags.String("input", "", "the input file (default: the cached input, or the day's committed input)")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check the files for changes")
+	flags.Parse(args)
+
+	if *day == 0 {
+		return errors.New("-day is required")
+	}
+
+	input, err := watchInput(*day, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	w := &dayWatcher{
+		day:      *day,
+		input:    input,
+		binary:   filepath.Join(dir, fmt.Sprintf("day%d", *day)),
+		previous: map[int]runner.Result{},
+	}
+	paths := []string{fmt.Sprintf("day%d", *day), fmt.Sprintf("cmd/day%d", *day), input}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("watching %s\n", strings.Join(paths, ", "))
+	w.check()
+	err = watch.Poll(ctx, paths, *interval, func(changed []string) {
+		fmt.Printf("\n%s %s changed\n", time.Now().Format("15:04:05"), strings.Join(changed, ", "))
+		w.check()
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// watchInput returns the path of the input to watch: the given path, the
+// cached input, or the day's committed input, fetching it into the cache if
+// there is neither.
+func watchInput(day int, path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	store, err := cli.InputStore()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(store.Path(day)); err == nil {
+		return store.Path(day), nil
+	}
+	if committed := cli.CommittedInput(day); inputcrypt.Exists(committed) {
+		return committed, nil
+	}
+
+	if _, err := cli.ReadInput(day, ""); err != nil {
+		return "", err
+	}
+	return store.Path(day), nil
+}
+
+// dayWatcher checks a day and remembers its last answers to compare against.
+type dayWatcher struct {
+	day      int
+	input    string
+	binary   string
+	previous map[int]runner.Result
+}
+
+// check builds the day's command, runs its example tests and solves both
+// parts of the input with the new build, printing how the answers changed.
+func (w *dayWatcher) check() {
+	build := exec.Command("go", "build", "-o", w.binary, fmt.Sprintf("./cmd/day%d", w.day))
+	if output, err := build.CombinedOutput(); err != nil {
+		fmt.Printf("build failed:\n%s", output)
+		return
+	}
+
+	test := exec.Command("go", "test", "-run", "^TestExamples$", fmt.Sprintf("./day%d", w.day))
+	if output, err := test.CombinedOutput(); err != nil {
+		fmt.Printf("examples failed:\n%s", output)
+	} else {
+		fmt.Println("examples: ok")
+	}
+
+	var results []runner.Result
+	for _, part := range solver.Parts {
+		// The error is decoded from its text, so it is compared by its text.
+		result := w.solve(part)
+		if result.Err != nil && result.Err.Error() == solver.ErrUnsolved.Error() {
+			fmt.Printf("part %d: unsolved\n", part)
+			continue
+		}
+		results = append(results, result)
+	}
+	watch.WriteResults(os.Stdout, w.previous, results)
+
+	for _, result := range results {
+		if result.Err == nil {
+			w.previous[result.Part] = result
+		}
+	}
+}
+
+// solve runs the built command on the input for a part and returns the Result
+// it prints.
+func (w *dayWatcher) solve(part int) runner.Result {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(w.binary, "-part", fmt.Sprint(part), "-format", cli.FormatJSON, "-input", w.input)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	runErr := cmd.Run()
+
+	var result runner.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		if runErr == nil {
+			runErr = err
+		}
+		return runner.Result{Day: w.day, Part: part, Err: fmt.Errorf("%v: %s", runErr, strings.TrimSpace(stderr.String()))}
+	}
+	return result
+}
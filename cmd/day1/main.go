@@ -0,0 +1,34 @@
+// Command day1 prints the day 1 answer for -part, 2 by default, as it did
+// before it took -part. Part 2 counts English number words, or those of
+// -words or -words-file, in either case with -fold-case. A line without any
+// digits is an error unless -no-digits says to skip it or count it as zero.
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/day1"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	s := &day1.Solver{}
+	flag.Func("words", "the languages of the number words for part 2, e.g. en,fr (one of "+strings.Join(day1.Languages(), ", ")+")", func(value string) error {
+		words, err := day1.WordTable(strings.Split(value, ",")...)
+		s.Words = words
+		return err
+	})
+	flag.Func("words-file", "a file of number words for part 2, a word and its digit on each line", func(path string) error {
+		words, err := day1.ReadWordsFile(path)
+		s.Words = words
+		return err
+	})
+	flag.BoolVar(&s.FoldCase, "fold-case", false, "match the letters A to Z of number words in either case")
+	flag.Func("no-digits", "what to do with a line without digits: error, skip or zero (default error)", func(name string) error {
+		noDigits, err := day1.ParseNoDigits(name)
+		s.NoDigits = noDigits
+		return err
+	})
+	cli.DayMain(1, s, 2, nil)
+}
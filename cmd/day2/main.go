@@ -0,0 +1,12 @@
+// Command day2 prints the day 2 answer for 'gametotals' (part 1) or
+// 'cubetotals' (part 2).
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day2"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(2, day2.Solver{}, 1, map[string]int{"gametotals": 1, "cubetotals": 2})
+}
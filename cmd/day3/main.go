@@ -0,0 +1,12 @@
+// Command day3 prints the day 3 answer for 'partnumbers' (part 1) or 'gears'
+// (part 2).
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day3"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(3, day3.Solver{}, 1, map[string]int{"partnumbers": 1, "gears": 2})
+}
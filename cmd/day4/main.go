@@ -0,0 +1,12 @@
+// Command day4 prints the day 4 answer for 'points' (part 1) or 'copies'
+// (part 2).
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day4"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(4, day4.Solver{}, 1, map[string]int{"points": 1, "copies": 2})
+}
@@ -0,0 +1,12 @@
+// Command day5 prints the day 5 answer for -part, 2 by default, as it did
+// before the shared runner.
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day5"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(5, day5.Solver{}, 2, nil)
+}
@@ -0,0 +1,11 @@
+// Command day6 prints the day 6 answer for -part.
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day6"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(6, day6.Solver{}, 1, nil)
+}
@@ -0,0 +1,11 @@
+// Command day7 prints the day 7 answer for -part.
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day7"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain(7, day7.Solver{}, 1, nil)
+}
@@ -1,117 +1,180 @@
-package main
+// Package day1 totals the calibration values in an input file.
+package day1
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"os"
-	"strconv"
-	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
 )
 
-var numberMap = map[string]string{
-	"one":   "1",
-	"two":   "2",
-	"three": "3",
-	"four":  "4",
-	"five":  "5",
-	"six":   "6",
-	"seven": "7",
-	"eight": "8",
-	"nine":  "9",
-	"1":     "1",
-	"2":     "2",
-	"3":     "3",
-	"4":     "4",
-	"5":     "5",
-	"6":     "6",
-	"7":     "7",
-	"8":     "8",
-	"9":     "9",
-	"0":     "0",
+// Policy says which tokens of a line stand for digits in its calibration
+// value, how they are looked for, and what is done with a line without any.
+// Tokens may overlap, as in "twone", and each counts, except a token that a
+// longer one ends with, such as the French "un" in the German "neun".
+type Policy struct {
+	Digits   bool           // The digits 0 to 9 stand for themselves.
+	Words    map[string]int // Words, and the digit each stands for.
+	Mode     Mode
+	NoDigits NoDigits
+	// FoldCase matches the letters A to Z of Words in either case. Words
+	// that differ only in those must stand for the same digit. Other
+	// letters, such as Ü, match only as Words has them, and accented ones
+	// only in the same form, composed or not; the built-in tables have
+	// them composed (NFC), and each accented word without its accents too.
+	FoldCase bool
 }
 
-var inputFile = "day1_input.txt"
+// Mode is how the first and last tokens of a line are looked for. Each mode
+// finds the same ones.
+type Mode int
+
+const (
+	// ScanEnds looks forward from the start of a line for its first token
+	// and backward from the end for its last, and stops at each once it is
+	// found, so it reads little of a long line.
+	ScanEnds Mode = iota
+	// ScanAll finds every token in a line in one pass forward.
+	ScanAll
+)
 
-// main prints the total calibration values in an input file.
-func main() {
-	file, err := os.Open(inputFile)
-	if err != nil {
-		panic(err)
-	}
+// NoDigits is what is done with a line without any tokens, such as a blank
+// line.
+type NoDigits int
+
+const (
+	// NoDigitsError stops with a *input.ParseError for the line.
+	NoDigitsError NoDigits = iota
+	// NoDigitsSkip leaves the line out.
+	NoDigitsSkip
+	// NoDigitsZero counts the line with a value of zero.
+	NoDigitsZero
+)
 
-	result, err := run(file)
-	if err != nil {
-		panic(err)
+// noDigitsNames are the names ParseNoDigits accepts.
+var noDigitsNames = map[string]NoDigits{
+	"error": NoDigitsError,
+	"skip":  NoDigitsSkip,
+	"zero":  NoDigitsZero,
+}
+
+// ParseNoDigits returns the NoDigits named error, skip or zero.
+func ParseNoDigits(name string) (NoDigits, error) {
+	noDigits, ok := noDigitsNames[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown policy %q for lines without digits: expected error, skip or zero", name)
 	}
+	return noDigits, nil
+}
 
-	fmt.Println(result)
+// EnglishWords are the digits one to nine spelled out.
+var EnglishWords = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
 }
 
-// getLineValue returns the first and last calibration values from a line by
-// recursively advancing one character and looking for a matching numbers, then
-// taking the first and last match.
-// E.g., "one7xctgtrtwoeightwovkv" would return 12.
-func getLineValue(line string, acc []string) int {
-	if len(line) == 0 {
-		result, _ := strconv.Atoi(acc[0] + acc[len(acc)-1])
-		return result
-	}
+var (
+	// DigitsOnly reads calibration values as part 1 does.
+	DigitsOnly = Policy{Digits: true}
+	// DigitsAndWords reads calibration values as part 2 does.
+	DigitsAndWords = Policy{Digits: true, Words: EnglishWords}
+)
 
-	for key, value := range numberMap {
-		if strings.HasPrefix(line, key) {
-			acc = append(acc, value)
-		}
+func init() {
+	solver.Register(1, "Trebuchet?!", Solver{})
+	solver.RegisterOracle(1, Oracle{})
+}
+
+// Solver solves day 1. The Solver registered, which aoc runs, is the zero
+// Solver; the day1 command sets its fields with -words, -words-file,
+// -fold-case and -no-digits.
+type Solver struct {
+	// Words are the number words part 2 counts, EnglishWords if nil. See
+	// WordTable and ReadWords.
+	Words map[string]int
+	// FoldCase matches Words in either case. See Policy.
+	FoldCase bool
+	// NoDigits is what both parts do with a line without any tokens.
+	NoDigits NoDigits
+}
+
+// Part1 sums the calibration values made of digits only.
+func (s Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(r, Policy{Digits: true, NoDigits: s.NoDigits}))
+}
+
+// Part2 sums the calibration values, including spelled out digits.
+func (s Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	words := s.Words
+	if words == nil {
+		words = EnglishWords
 	}
+	return solver.Int(run(r, Policy{Digits: true, Words: words, NoDigits: s.NoDigits, FoldCase: s.FoldCase}))
+}
 
-	return getLineValue(line[1:], acc)
+// CalibrationValue returns the calibration value of a line, counting spelled
+// out digits. E.g., "xtwone3four" would return 24.
+func CalibrationValue(line string) (int, error) {
+	return wordsMatcher.Value(line)
 }
 
-// run reads through calibration lines and returns their sum or an error.
-func run(reader io.Reader) (int, error) {
-	scanner := bufio.NewScanner(reader)
+// wordsMatcher is the Matcher for DigitsAndWords.
+var wordsMatcher = NewMatcher(DigitsAndWords)
+
+// run reads through calibration lines and returns the sum of their values
+// under policy, or an error.
+func run(reader io.Reader, policy Policy) (int, error) {
+	values, err := Values(reader, policy)
+	if err != nil {
+		return 0, err
+	}
+
 	total := 0
-	for scanner.Scan() {
-		total += getLineValue(scanner.Text(), []string{})
+	for _, value := range values {
+		total += value
 	}
 
 	return total, nil
 }
 
-// func main() {
-// 	file, err := os.Open("day1_input.txt")
-// 	if err != nil {
-// 		panic(err)
-// 	}
-
-// 	result, err := run(file)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-
-// 	fmt.Println(result)
-// }
-
-// func getLineValue(line string) int {
-// 	numbers := []rune{}
-// 	for _, char := range line {
-// 		if unicode.IsDigit(char) {
-// 			numbers = append(numbers, char)
-// 		}
-// 	}
-
-// 	firstLast := []rune{numbers[0], numbers[len(numbers)-1]}
-// 	result, _ := strconv.Atoi(string(firstLast))
-
-// 	return result
-// }
-
-// func run(reader io.Reader) (int, error) {
-// 	scanner := bufio.NewScanner(reader)
-// 	total := 0
-// 	for scanner.Scan() {
-// 		total += getLineValue(scanner.Text())
-// 	}
-
-// 	return total, nil
-// }
+// Values returns the calibration value of each line under policy. A line
+// without any tokens has none with NoDigitsSkip, so there may be fewer values
+// than lines.
+func Values(reader io.Reader, policy Policy) ([]int, error) {
+	lines, err := input.Lines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := NewMatcher(policy)
+	values := make([]int, 0, len(lines))
+	for i, line := range lines {
+		value, ok := matcher.value(line)
+		if !ok {
+			switch policy.NoDigits {
+			case NoDigitsSkip:
+				continue
+			case NoDigitsZero:
+			default:
+				return nil, input.AtLine(errNoDigits(), i+1)
+			}
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// errNoDigits returns the error for a line without any tokens.
+func errNoDigits() error {
+	return &input.ParseError{Day: 1, Msg: "no digits in line"}
+}
@@ -1,72 +1,527 @@
-package main
+package day1
 
 import (
-	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
 )
 
-func TestGetLineValue(t *testing.T) {
+func TestMatcherValue(t *testing.T) {
+	customWords := Policy{Words: map[string]int{"un": 1, "deux": 2, "dix": 0, "di": 9}}
 	testCases := []struct {
 		input    string
+		policy   Policy
 		expected int
 	}{
-		{"two1nine", 29},
-		{"eightwothree", 83},
-		{"abcone2threexyz", 13},
-		{"xtwone3four", 24},
-		{"4nineeightseven2", 42},
-		{"zoneight234", 14},
-		{"7pqrstsixteen", 76},
-		{"one7xctgtrtwoeightwovkv", 12},
+		{"1abc2", DigitsOnly, 12},
+		{"pqr3stu8vwx", DigitsOnly, 38},
+		{"a1b2c3d4e5f", DigitsOnly, 15},
+		{"treb7uchet", DigitsOnly, 77},
+		{"two1nine", DigitsOnly, 11},
+		{"one7xctgtrtwoeightwovkv", DigitsOnly, 77},
+		{"two1nine", DigitsAndWords, 29},
+		{"eightwothree", DigitsAndWords, 83},
+		{"abcone2threexyz", DigitsAndWords, 13},
+		{"xtwone3four", DigitsAndWords, 24},
+		{"4nineeightseven2", DigitsAndWords, 42},
+		{"zoneight234", DigitsAndWords, 14},
+		{"7pqrstsixteen", DigitsAndWords, 76},
+		{"one7xctgtrtwoeightwovkv", DigitsAndWords, 12},
+		{"0x", DigitsAndWords, 0},
+		{"undeux", customWords, 12},
+		{"deux3un", customWords, 21},
+		{"undix", customWords, 10},
+		{"dideux", customWords, 92},
+		{"dix", customWords, 0},
+		{"twone", DigitsAndWords, 21},
+		{"twonexx5", DigitsAndWords, 25},
+		{"5xxtwone", DigitsAndWords, 51},
+		{"twone5twone", DigitsAndWords, 21},
+		{"oneight", DigitsAndWords, 18},
+		{"eightwoxxxxxxxxxxxxxxoneight", DigitsAndWords, 88},
+	}
+
+	for _, mode := range []Mode{ScanEnds, ScanAll} {
+		for _, tc := range testCases {
+			tc.policy.Mode = mode
+			got, err := NewMatcher(tc.policy).Value(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v for %q in mode %v, but got %v", tc.expected, tc.input, mode, got)
+			}
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []Match
+	}{
+		{"eightwo", []Match{{0, 5, 8}, {4, 3, 2}}},
+		{"oneight", []Match{{0, 3, 1}, {2, 5, 8}}},
+		{"x3twonex", []Match{{1, 1, 3}, {2, 3, 2}, {4, 3, 1}}},
+		{"sevenineight", []Match{{0, 5, 7}, {4, 4, 9}, {7, 5, 8}}},
+		{"nothing", nil},
 	}
 
+	matcher := NewMatcher(DigitsAndWords)
 	for _, tc := range testCases {
-		got := getLineValue(tc.input, []string{})
-		if got != tc.expected {
-			t.Errorf("Expected %v, but got %v", tc.expected, got)
+		got := matcher.Matches(tc.input)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("Expected %v for %q, but got %v", tc.expected, tc.input, got)
 		}
 	}
 }
 
 func TestRun(t *testing.T) {
-	buffer := bytes.NewBufferString("two1nine\neightwothree\nabcone2threexyz\nxtwone3four\n4nineeightseven2\nzoneight234\n7pqrstsixteen\none7xctgtrtwoeightwovkv")
-	got, err := run(buffer)
+	reader := strings.NewReader("two1nine\nthree\n")
+	_, err := run(reader, DigitsOnly)
+	expected := "day 1: line 2: no digits in line"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
+	}
+
+	got, err := run(strings.NewReader("two1nine\nthree\n"), DigitsAndWords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 62 {
+		t.Fatalf("Expected %v, but got %v", 62, got)
+	}
+}
+
+func TestValues(t *testing.T) {
+	const lines = "two1nine\n\nthree\nnothing\n7\n\n"
+	testCases := []struct {
+		noDigits NoDigits
+		expected []int
+		err      string
+	}{
+		{NoDigitsError, nil, "day 1: line 2: no digits in line"},
+		{NoDigitsSkip, []int{11, 77}, ""},
+		{NoDigitsZero, []int{11, 0, 0, 0, 77, 0}, ""},
+	}
+
+	for _, tc := range testCases {
+		got, err := Values(strings.NewReader(lines), Policy{Digits: true, NoDigits: tc.noDigits})
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("Expected %q, but got %v", tc.err, err)
+			}
+			var parseErr *input.ParseError
+			if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+				t.Fatalf("Expected a *input.ParseError at line 2, but got %#v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Expected %v, but got %v", tc.expected, got)
+		}
+	}
+}
+
+func TestSolverNoDigits(t *testing.T) {
+	const lines = "1abc2\n\nthree\n"
+	const noDigitsErr = "day 1: line 2: no digits in line"
+	testCases := []struct {
+		name  string
+		part1 string
+		part2 string
+	}{
+		{"error", noDigitsErr, noDigitsErr},
+		{"skip", "12", "45"},
+		{"zero", "12", "45"},
+	}
+
+	for _, tc := range testCases {
+		noDigits, err := ParseNoDigits(tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := Solver{NoDigits: noDigits}
+		for part, expected := range map[int]string{1: tc.part1, 2: tc.part2} {
+			got, err := solver.Puzzle{Day: 1, Solver: s}.Solve(context.Background(), part, strings.NewReader(lines))
+			if err != nil {
+				got = err.Error()
+			}
+			if got != expected {
+				t.Errorf("Expected %q for part %d with %s, but got %q", expected, part, tc.name, got)
+			}
+		}
+	}
+
+	if _, err := ParseNoDigits("ignore"); err == nil {
+		t.Fatalf("Expected an error for an unknown policy")
+	}
+}
+
+func TestLocalizedWords(t *testing.T) {
+	words, err := WordTable("en", "fr", "de", "es")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		input    string
+		expected int
+	}{
+		{"xcinquatre", 54},
+		{"nueveinsieben", 97},
+		{"zéro7", 7},
+		{"zero7fünf", 5},
+		{"fuenfzwei", 52},
+		{"unodos", 12},
+		{"treizeun", 11},
+		{"sixteensechs", 66},
+		{"ochthreeightwo", 32},
+		{"huitneunueve", 89},
+		{"siebensiete", 77},
+		{"cincinqo", 55},
+		// Of the words that end at the same index, only the longest counts,
+		// so the French un in German neun doesn't.
+		{"neun", 99},
+		{"dreineun", 39},
+		{"neunun", 91},
+	}
+
+	for _, mode := range []Mode{ScanEnds, ScanAll} {
+		matcher := NewMatcher(Policy{Digits: true, Words: words, Mode: mode})
+		for _, tc := range testCases {
+			got, err := matcher.Value(tc.input)
+			if err != nil {
+				t.Fatalf("%q: %v", tc.input, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v for %q in mode %v, but got %v", tc.expected, tc.input, mode, got)
+			}
+			if expected, _ := bruteForceLineValue(tc.input, Policy{Digits: true, Words: words}); expected != got {
+				t.Errorf("Expected %v for %q by brute force, but got %v", expected, tc.input, got)
+			}
+		}
+	}
+}
+
+// TestWordsAloneInAnyTable checks that each number word of a language stands
+// for its own digit whichever other languages it is combined with.
+func TestWordsAloneInAnyTable(t *testing.T) {
+	all, err := WordTable(Languages()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matcher := NewMatcher(Policy{Words: all})
+
+	for _, language := range Languages() {
+		words, err := WordTable(language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for word, digit := range words {
+			if got, err := matcher.Value(word); err != nil || got != 11*digit {
+				t.Errorf("Expected %v for %q, but got %v, %v", 11*digit, word, got, err)
+			}
+		}
+	}
+}
+
+func TestFoldCase(t *testing.T) {
+	words, err := WordTable("en", "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		input    string
+		words    map[string]int
+		foldCase bool
+		expected int
+	}{
+		{"TWO1", EnglishWords, false, 11},
+		{"TWO1", EnglishWords, true, 21},
+		{"EIGHTwo", EnglishWords, true, 82},
+		{"Fünf3", words, true, 53},
+		{"FUENF3", words, true, 53},
+		// Only A to Z fold, so FÜNF isn't fünf.
+		{"FÜNF3", words, true, 33},
+		{"einszWEI", map[string]int{"EINS": 1, "Zwei": 2}, true, 12},
+		{"einszWEI", map[string]int{"EINS": 1, "Zwei": 2}, false, 0},
+	}
+
+	for _, mode := range []Mode{ScanEnds, ScanAll} {
+		for _, tc := range testCases {
+			policy := Policy{Digits: true, Words: tc.words, Mode: mode, FoldCase: tc.foldCase}
+			got, _ := NewMatcher(policy).Value(tc.input)
+			if got != tc.expected {
+				t.Errorf("Expected %v for %q with FoldCase %v in mode %v, but got %v", tc.expected, tc.input, tc.foldCase, mode, got)
+			}
+			if expected, _ := bruteForceLineValue(tc.input, policy); expected != got {
+				t.Errorf("Expected %v for %q by brute force, but got %v", expected, tc.input, got)
+			}
+		}
+	}
+
+	// The registered Solver agrees with the Oracle, which doesn't fold case.
+	for _, tc := range []struct {
+		s        Solver
+		expected string
+	}{
+		{Solver{}, "11"},
+		{Solver{FoldCase: true}, "21"},
+	} {
+		got, err := tc.s.Part2(context.Background(), strings.NewReader("TWO1\n"))
+		if err != nil || got != tc.expected {
+			t.Fatalf("Expected %v, but got %v, %v", tc.expected, got, err)
+		}
+	}
+}
+
+func TestWordTable(t *testing.T) {
+	words, err := WordTable("fr", "es")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != len(FrenchWords)+len(SpanishWords) || words["un"] != 1 || words["uno"] != 1 {
+		t.Fatalf("Expected the French and Spanish words, but got %v", words)
+	}
+
+	if _, err := WordTable("en", "xx"); err == nil || err.Error() != `no number words for "xx"` {
+		t.Fatalf("Expected an unknown language, but got %v", err)
+	}
+
+	table := map[string]int{"six": 6}
+	expected := `"six" stands for both 6 and 7`
+	if err := mergeWords(table, map[string]int{"six": 7}); err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
+	}
+}
+
+func TestReadWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader("# Italian\nuno 1\n\n  due\t2\ntre 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int{"uno": 1, "due": 2, "tre": 3}
+	if !reflect.DeepEqual(words, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, words)
+	}
+
+	testCases := []struct {
+		table    string
+		expected string
+	}{
+		{"uno 1\ndue\n", `day 1: line 2: expected a word and a digit, but got "due"`},
+		{"uno 1 2\n", `day 1: line 1: expected a word and a digit, but got "uno 1 2"`},
+		{"uno one\n", `day 1: line 1, column 5: invalid number "one": invalid syntax`},
+		{"dieci 10\n", `day 1: line 1: "dieci" stands for 10, which is not a digit`},
+		{"uno 1\nuno 2\n", `day 1: line 2: "uno" stands for both 1 and 2`},
+	}
+	for _, tc := range testCases {
+		_, err := ReadWords(strings.NewReader(tc.table))
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	golden.Run(t, 1)
+}
+
+func TestOracle(t *testing.T) {
+	differential.Run(t, 1)
+}
+
+func TestOracleNoDigits(t *testing.T) {
+	for _, part := range solver.Parts {
+		in := "1abc2\nxyz\n"
+		_, expected := solver.Puzzle{Day: 1, Solver: Solver{}}.Solve(context.Background(), part, strings.NewReader(in))
+		_, err := solver.Puzzle{Day: 1, Solver: Oracle{}}.Solve(context.Background(), part, strings.NewReader(in))
+		if err == nil || expected == nil || err.Error() != expected.Error() {
+			t.Fatalf("Expected %v for part %d, but got %v", expected, part, err)
+		}
+	}
+}
+
+// allMatcher is the Matcher for DigitsAndWords that finds every token.
+var allMatcher = NewMatcher(Policy{Digits: true, Words: EnglishWords, Mode: ScanAll})
+
+func FuzzMatcherValue(f *testing.F) {
+	for _, line := range strings.Split(golden.Input(f, "example2.txt"), "\n") {
+		f.Add(line)
+	}
+	f.Add("nueveinsiebenzérofünf")
+	f.Add("NeunUN")
+
+	localized, err := WordTable(Languages()...)
 	if err != nil {
-		t.Error(err)
-	}
-	expected := 293
-	if got != expected {
-		t.Errorf("Expected %v, but got %v", expected, got)
-	}
-}
-
-// func TestGetLineValue(t *testing.T) {
-// 	testCases := []struct {
-// 		input    string
-// 		expected int
-// 	}{
-// 		{"1abc2", 12},
-// 		{"pqr3stu8vwx", 38},
-// 		{"a1b2c3d4e5f", 15},
-// 		{"treb7uchet", 77},
-// 	}
-
-// 	for _, tc := range testCases {
-// 		got := getLineValue(tc.input)
-// 		if got != tc.expected {
-// 			t.Errorf("Expected %v, but got %v", tc.expected, got)
-// 		}
-// 	}
-// }
-
-// func TestRun(t *testing.T) {
-// 	buffer := bytes.NewBufferString("1abc2\npqr3stu8vwx\na1b2c3d4e5f\ntreb7uchet")
-// 	got, err := run(buffer)
-// 	if err != nil {
-// 		t.Error(err)
-// 	}
-// 	expected := 142
-// 	if got != expected {
-// 		t.Errorf("Expected %v, but got %v", expected, got)
-// 	}
-// }
+		f.Fatal(err)
+	}
+	policies := []Policy{DigitsAndWords, {Digits: true, Words: localized}, {Digits: true, Words: localized, FoldCase: true}}
+	var matchers [][]*Matcher
+	for _, policy := range policies {
+		var byMode []*Matcher
+		for _, mode := range []Mode{ScanEnds, ScanAll} {
+			policy.Mode = mode
+			byMode = append(byMode, NewMatcher(policy))
+		}
+		matchers = append(matchers, byMode)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		// No English word ends another, so the rule of bruteForceLineValue
+		// gives the same values as before Matcher.
+		before, beforeErr := recursiveLineValue(line, DigitsAndWords, []int{})
+		if expected, expectedErr := bruteForceLineValue(line, DigitsAndWords); before != expected || (beforeErr == nil) != (expectedErr == nil) {
+			t.Fatalf("Expected %v, %v for %q as before Matcher, but got %v, %v", before, beforeErr, line, expected, expectedErr)
+		}
+
+		for i, policy := range policies {
+			expected, expectedErr := bruteForceLineValue(line, policy)
+			for _, matcher := range matchers[i] {
+				got, err := matcher.Value(line)
+				if err == nil && (got < 0 || got > 99) {
+					t.Fatalf("Expected a two digit value, but got %d", got)
+				}
+				if got != expected || (err == nil) != (expectedErr == nil) {
+					t.Fatalf("Expected %v, %v for %q in mode %v, but got %v, %v", expected, expectedErr, line, matcher.mode, got, err)
+				}
+			}
+		}
+	})
+}
+
+// recursiveLineValue is how a calibration value was found before Matcher, by
+// advancing one byte at a time and trying every token at each. It is kept to
+// check and benchmark Matcher against.
+func recursiveLineValue(line string, policy Policy, acc []int) (int, error) {
+	if len(line) == 0 {
+		if len(acc) == 0 {
+			return 0, &input.ParseError{Day: 1, Msg: "no digits in line"}
+		}
+		return 10*acc[0] + acc[len(acc)-1], nil
+	}
+
+	value, length := 0, 0
+	if policy.Digits && line[0] >= '0' && line[0] <= '9' {
+		value, length = int(line[0]-'0'), 1
+	}
+	for word, digit := range policy.Words {
+		if len(word) > length && strings.HasPrefix(line, word) {
+			value, length = digit, len(word)
+		}
+	}
+	if length > 0 {
+		acc = append(acc, value)
+	}
+
+	return recursiveLineValue(line[1:], policy, acc)
+}
+
+// bruteForceLineValue finds a calibration value by trying every substring of
+// line as a token, in lower case with FoldCase. Of the tokens that end at the
+// same index only the longest counts, and of those left that start at the
+// same index, the longest.
+func bruteForceLineValue(line string, policy Policy) (int, error) {
+	words := policy.Words
+	if policy.FoldCase {
+		words = map[string]int{}
+		for word, digit := range policy.Words {
+			words[lowerASCII(word)] = digit
+		}
+	}
+
+	longest := 1
+	for word := range words {
+		longest = max(longest, len(word))
+	}
+
+	type token struct{ start, end, digit int }
+	var tokens []token
+	for start := 0; start < len(line); start++ {
+		for end := start + 1; end <= min(start+longest, len(line)); end++ {
+			text := line[start:end]
+			if policy.FoldCase {
+				text = lowerASCII(text)
+			}
+			if digit, ok := words[text]; ok {
+				tokens = append(tokens, token{start, end, digit})
+			}
+			if policy.Digits && len(text) == 1 && text[0] >= '0' && text[0] <= '9' {
+				tokens = append(tokens, token{start, end, int(text[0] - '0')})
+			}
+		}
+	}
+
+	var counted []token
+	for _, t := range tokens {
+		covered := false
+		for _, u := range tokens {
+			if u.end == t.end && u.start < t.start {
+				covered = true
+			}
+		}
+		if !covered {
+			counted = append(counted, t)
+		}
+	}
+	if len(counted) == 0 {
+		return 0, &input.ParseError{Day: 1, Msg: "no digits in line"}
+	}
+
+	first, last := counted[0], counted[0]
+	for _, t := range counted {
+		if t.start < first.start || (t.start == first.start && t.end > first.end) {
+			first = t
+		}
+		if t.start > last.start || (t.start == last.start && t.end > last.end) {
+			last = t
+		}
+	}
+	return 10*first.digit + last.digit, nil
+}
+
+// longLine returns a line of about size bytes of letters, digits and number
+// words, overlapping ones included.
+func longLine(size int) string {
+	const chunk = "xtwone3fourabcoeightwoqzsevenine5nnkkfourthree"
+	return strings.Repeat(chunk, size/len(chunk))
+}
+
+func BenchmarkLineValue(b *testing.B) {
+	for _, size := range []int{1 << 20, 4 << 20} {
+		line := longLine(size)
+		b.Run(fmt.Sprintf("ends/%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(line)))
+			for i := 0; i < b.N; i++ {
+				wordsMatcher.Value(line)
+			}
+		})
+		b.Run(fmt.Sprintf("all/%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(line)))
+			for i := 0; i < b.N; i++ {
+				allMatcher.Value(line)
+			}
+		})
+		if size > 1<<20 {
+			// A frame a byte overflows the stack.
+			continue
+		}
+		b.Run(fmt.Sprintf("recursive/%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(line)))
+			for i := 0; i < b.N; i++ {
+				recursiveLineValue(line, DigitsAndWords, []int{})
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package day1
+
+// Match is a token found in a line.
+type Match struct {
+	Start  int // The index of the token's first byte.
+	Length int
+	Digit  int
+}
+
+// output is a token that ends in a state of an automaton.
+type output struct {
+	length int
+	digit  int
+}
+
+// Matcher finds the tokens of a Policy in a line, overlapping ones included.
+// It is an Aho–Corasick automaton with its failure links folded into the
+// transitions, so each byte of a line is one table lookup, and a second
+// automaton of the tokens reversed to look for the last token from the end.
+type Matcher struct {
+	forward  *automaton
+	backward *automaton
+	longest  int // The length of the longest token.
+	mode     Mode
+}
+
+// NewMatcher builds the Matcher for a policy.
+func NewMatcher(policy Policy) *Matcher {
+	m := &Matcher{forward: newAutomaton(), backward: newAutomaton(), mode: policy.Mode}
+	add := func(token string, digit int) {
+		if policy.FoldCase {
+			token = lowerASCII(token)
+		}
+		m.forward.add(token, digit)
+		m.backward.add(reverse(token), digit)
+		m.longest = max(m.longest, len(token))
+	}
+
+	for word, digit := range policy.Words {
+		add(word, digit)
+	}
+	// A digit that is also in Words stands for itself.
+	if policy.Digits {
+		for digit := 0; digit <= 9; digit++ {
+			add(string(rune('0'+digit)), digit)
+		}
+	}
+
+	m.forward.link()
+	m.backward.link()
+	if policy.FoldCase {
+		m.forward.foldCase()
+		m.backward.foldCase()
+	}
+	return m
+}
+
+// lowerASCII returns token with the letters A to Z in lower case.
+func lowerASCII(token string) string {
+	lowered := []byte(token)
+	for i, b := range lowered {
+		if 'A' <= b && b <= 'Z' {
+			lowered[i] = b + 'a' - 'A'
+		}
+	}
+	return string(lowered)
+}
+
+// reverse returns token with its bytes, not its runes, in reverse order, as
+// a line is read backward a byte at a time.
+func reverse(token string) string {
+	reversed := make([]byte, len(token))
+	for i := 0; i < len(token); i++ {
+		reversed[len(token)-1-i] = token[i]
+	}
+	return string(reversed)
+}
+
+// automaton matches a set of tokens.
+type automaton struct {
+	next    []int32    // The state after a byte, at next[256*state+byte].
+	outputs [][]output // The tokens that end in each state, longest first.
+}
+
+func newAutomaton() *automaton {
+	a := &automaton{}
+	a.addState()
+	return a
+}
+
+// addState adds a state without transitions and returns it.
+func (a *automaton) addState() int32 {
+	for i := 0; i < 256; i++ {
+		a.next = append(a.next, -1)
+	}
+	a.outputs = append(a.outputs, nil)
+	return int32(len(a.outputs) - 1)
+}
+
+// add adds a token to the trie of states, replacing a token already added
+// with the same text.
+func (a *automaton) add(token string, digit int) {
+	if token == "" {
+		return
+	}
+
+	state := int32(0)
+	for i := 0; i < len(token); i++ {
+		transition := 256*int(state) + int(token[i])
+		if a.next[transition] < 0 {
+			// addState grows a.next, so it is called before indexing it.
+			added := a.addState()
+			a.next[transition] = added
+		}
+		state = a.next[transition]
+	}
+	a.outputs[state] = []output{{length: len(token), digit: digit}}
+}
+
+// link turns the trie into the automaton. Breadth first, each state's failure
+// state, the longest proper suffix of its text that is also a state, is
+// already complete, so a missing transition is taken from it, and the tokens
+// that end in it also end in the state.
+func (a *automaton) link() {
+	fail := make([]int32, len(a.outputs))
+	var queue []int32
+	for b := 0; b < 256; b++ {
+		if child := a.next[b]; child < 0 {
+			a.next[b] = 0
+		} else {
+			queue = append(queue, child)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		a.outputs[state] = append(a.outputs[state], a.outputs[fail[state]]...)
+
+		for b := 0; b < 256; b++ {
+			i := 256*int(state) + b
+			fallback := a.next[256*int(fail[state])+b]
+			if child := a.next[i]; child < 0 {
+				a.next[i] = fallback
+			} else {
+				fail[child] = fallback
+				queue = append(queue, child)
+			}
+		}
+	}
+}
+
+// foldCase gives the letters A to Z the transitions of a to z, for an
+// automaton of tokens in lower case.
+func (a *automaton) foldCase() {
+	for state := 0; state < len(a.outputs); state++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			a.next[256*state+int(b)] = a.next[256*state+int(b+'a'-'A')]
+		}
+	}
+}
+
+// scan calls found for every token in line that counts, in the order the
+// tokens end. Of the tokens that end at an index, only the longest counts.
+func (m *Matcher) scan(line string, found func(end int, out output)) {
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		state = m.forward.next[256*int(state)+int(line[i])]
+		if outputs := m.forward.outputs[state]; len(outputs) > 0 {
+			found(i+1, outputs[0])
+		}
+	}
+}
+
+// Matches returns every token in line that counts, in the order they end.
+// E.g., "eightwo" would return eight at 0 and two at 4, and "neun" with
+// French and German words only neun, not the French un inside it.
+func (m *Matcher) Matches(line string) []Match {
+	var matches []Match
+	m.scan(line, func(end int, out output) {
+		matches = append(matches, Match{Start: end - out.length, Length: out.length, Digit: out.digit})
+	})
+	return matches
+}
+
+// Value returns the calibration value of a line: the digits of its first and
+// last tokens. Of tokens that start at the same index the longest counts, and
+// of those that end at the same index, only the longest does. It returns an
+// error for a line without any tokens.
+func (m *Matcher) Value(line string) (int, error) {
+	value, ok := m.value(line)
+	if !ok {
+		return 0, errNoDigits()
+	}
+	return value, nil
+}
+
+// value returns the calibration value of a line, and whether it has one.
+func (m *Matcher) value(line string) (int, bool) {
+	var first, last Match
+	if m.mode == ScanAll {
+		first, last = m.ends(line)
+	} else {
+		first, last = m.first(line), m.last(line)
+	}
+
+	if first.Start < 0 {
+		return 0, false
+	}
+	return 10*first.Digit + last.Digit, true
+}
+
+// ends returns the first and last tokens in line from every token in it. Their
+// Start is -1 if there are none.
+func (m *Matcher) ends(line string) (Match, Match) {
+	first, last := Match{Start: -1}, Match{Start: -1}
+	m.scan(line, func(end int, out output) {
+		match := Match{Start: end - out.length, Length: out.length, Digit: out.digit}
+		if first.Start < 0 || match.Start < first.Start || (match.Start == first.Start && match.Length > first.Length) {
+			first = match
+		}
+		if match.Start > last.Start || (match.Start == last.Start && match.Length > last.Length) {
+			last = match
+		}
+	})
+	return first, last
+}
+
+// first returns the first token in line, or a Match with Start -1 if there is
+// none. Tokens are found as they end, the longest of those that end at an
+// index first, so once one is found the scan goes on only until no token that
+// starts at or before it can still end.
+func (m *Matcher) first(line string) Match {
+	first := Match{Start: -1}
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		if first.Start >= 0 && i-m.longest >= first.Start {
+			break
+		}
+
+		state = m.forward.next[256*int(state)+int(line[i])]
+		outputs := m.forward.outputs[state]
+		if len(outputs) == 0 {
+			continue
+		}
+		out := outputs[0]
+		start := i + 1 - out.length
+		if first.Start < 0 || start < first.Start || (start == first.Start && out.length > first.Length) {
+			first = Match{Start: start, Length: out.length, Digit: out.digit}
+		}
+	}
+	return first
+}
+
+// last returns the last token in line, or a Match with Start -1 if there is
+// none. Scanning backward with the reversed tokens, the tokens that start at
+// an index are found as it is read, longest first. The first found is the
+// last token unless a longer one found later ends with it; once no such token
+// can still start, it is.
+func (m *Matcher) last(line string) Match {
+	var found []Match // Tokens no longer one is known to end with, latest first.
+	state := int32(0)
+	for i := len(line) - 1; i >= 0; i-- {
+		if len(found) > 0 && i < found[0].Start+found[0].Length-m.longest {
+			return found[0]
+		}
+
+		state = m.backward.next[256*int(state)+int(line[i])]
+		for _, out := range m.backward.outputs[state] {
+			match := Match{Start: i, Length: out.length, Digit: out.digit}
+			kept := found[:0]
+			for _, f := range found {
+				if f.Start+f.Length != match.Start+match.Length {
+					kept = append(kept, f)
+				}
+			}
+			found = append(kept, match)
+		}
+	}
+
+	if len(found) > 0 {
+		return found[0]
+	}
+	return Match{Start: -1}
+}
@@ -0,0 +1,51 @@
+package day1
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+var digitWords = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// Oracle solves day 1 by checking for every digit, and with words every
+// number word, at every position of a line. It is for checking Solver
+// against, and only solves valid inputs.
+type Oracle struct{}
+
+// Part1 sums the calibration values made of digits only.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, false))
+}
+
+// Part2 sums the calibration values, including spelled out digits.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, true))
+}
+
+func oracleRun(r io.Reader, words bool) (int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for i, line := range lines {
+		var digits []int
+		for start := range line {
+			for digit, word := range digitWords {
+				if line[start] == byte('0'+digit) || (words && digit > 0 && strings.HasPrefix(line[start:], word)) {
+					digits = append(digits, digit)
+				}
+			}
+		}
+		if len(digits) == 0 {
+			return 0, input.AtLine(errNoDigits(), i+1)
+		}
+		total += 10*digits[0] + digits[len(digits)-1]
+	}
+	return total, nil
+}
@@ -0,0 +1,182 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+)
+
+// FrenchWords, GermanWords and SpanishWords are the digits zero to nine
+// spelled out. Accented words are in composed form, as most text is, and are
+// also given without their accents as they are often typed.
+var (
+	FrenchWords = map[string]int{
+		"zéro":   0,
+		"zero":   0,
+		"un":     1,
+		"deux":   2,
+		"trois":  3,
+		"quatre": 4,
+		"cinq":   5,
+		"six":    6,
+		"sept":   7,
+		"huit":   8,
+		"neuf":   9,
+	}
+	GermanWords = map[string]int{
+		"null":   0,
+		"eins":   1,
+		"zwei":   2,
+		"drei":   3,
+		"vier":   4,
+		"fünf":   5,
+		"fuenf":  5,
+		"sechs":  6,
+		"sieben": 7,
+		"acht":   8,
+		"neun":   9,
+	}
+	SpanishWords = map[string]int{
+		"cero":   0,
+		"uno":    1,
+		"dos":    2,
+		"tres":   3,
+		"cuatro": 4,
+		"cinco":  5,
+		"seis":   6,
+		"siete":  7,
+		"ocho":   8,
+		"nueve":  9,
+	}
+)
+
+// wordTables are the tables of number words by language.
+var wordTables = map[string]map[string]int{
+	"en": EnglishWords,
+	"fr": FrenchWords,
+	"de": GermanWords,
+	"es": SpanishWords,
+}
+
+// RegisterWords adds a table of number words for a language, to be found by
+// WordTable. It panics if the language already has one or a word doesn't stand
+// for a digit, as it is meant to be called from an init function.
+func RegisterWords(language string, words map[string]int) {
+	if _, ok := wordTables[language]; ok {
+		panic(fmt.Sprintf("day1: number words for %q registered twice", language))
+	}
+	if err := checkWords(words); err != nil {
+		panic(fmt.Sprintf("day1: number words for %q: %v", language, err))
+	}
+	wordTables[language] = words
+}
+
+// Languages returns the languages with a table of number words, sorted.
+func Languages() []string {
+	var languages []string
+	for language := range wordTables {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// WordTable returns the number words of every language given, in one table,
+// to match them all in the same line. Words the languages share, such as
+// "six", must stand for the same digit in each.
+func WordTable(languages ...string) (map[string]int, error) {
+	table := map[string]int{}
+	for _, language := range languages {
+		words, ok := wordTables[language]
+		if !ok {
+			return nil, fmt.Errorf("no number words for %q", language)
+		}
+		if err := mergeWords(table, words); err != nil {
+			return nil, fmt.Errorf("%s: %w", language, err)
+		}
+	}
+	return table, nil
+}
+
+// ReadWords reads a table of number words, a word and the digit it stands
+// for on each line:
+//
+//	# Italian
+//	uno 1
+//	due 2
+//
+// Blank lines and lines starting with # are ignored.
+func ReadWords(r io.Reader) (map[string]int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	words := map[string]int{}
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, &input.ParseError{Day: 1, Line: i + 1, Msg: fmt.Sprintf("expected a word and a digit, but got %q", line)}
+		}
+
+		digit, err := input.Atoi(1, fields[1], strings.Index(line, fields[1])+1)
+		if err != nil {
+			return nil, input.AtLine(err, i+1)
+		}
+		if err := mergeWords(words, map[string]int{fields[0]: digit}); err != nil {
+			return nil, &input.ParseError{Day: 1, Line: i + 1, Msg: err.Error()}
+		}
+	}
+	return words, nil
+}
+
+// ReadWordsFile reads a table of number words from a file. See ReadWords.
+func ReadWordsFile(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	words, err := ReadWords(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return words, nil
+}
+
+// mergeWords adds words to table. A word must stand for a digit, and for the
+// same one as it already does in table.
+func mergeWords(table map[string]int, words map[string]int) error {
+	if err := checkWords(words); err != nil {
+		return err
+	}
+	for word, digit := range words {
+		if existing, ok := table[word]; ok && existing != digit {
+			return fmt.Errorf("%q stands for both %d and %d", word, existing, digit)
+		}
+		table[word] = digit
+	}
+	return nil
+}
+
+// checkWords returns an error for a word that is empty or doesn't stand for a
+// digit.
+func checkWords(words map[string]int) error {
+	for word, digit := range words {
+		if word == "" {
+			return fmt.Errorf("empty number word")
+		}
+		if digit < 0 || digit > 9 {
+			return fmt.Errorf("%q stands for %d, which is not a digit", word, digit)
+		}
+	}
+	return nil
+}
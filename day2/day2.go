@@ -1,86 +1,81 @@
-package main
+// Package day2 totals cube games: the sum of the possible game numbers for
+// part 1, or the sum of each game's cube power for part 2.
+package day2
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"math"
-	"os"
 	"regexp"
-	"strconv"
 	"strings"
-)
 
-const filename = "day2_input.txt"
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
 var (
 	bagMap     = map[string]int{"red": 12, "blue": 14, "green": 13}
 	cubesRegex = regexp.MustCompile(`(?:\d+ \w+)`)
-	gameRegex  = regexp.MustCompile(`Game (\d+)`)
+	gameRegex  = regexp.MustCompile(`^Game (\d+):`)
 )
 
+// Cubes is a number of cubes of one color revealed from the bag.
 type Cubes struct {
-	color  string
-	number int
+	Color  string
+	Number int
 }
 
+// Game is a game number and every set of cubes revealed during it.
 type Game struct {
-	number int
-	cubes  []Cubes
+	Number int
+	Cubes  []Cubes
 }
 
-type GameType int
+type gameType int
 
 const (
-	GameTotals GameType = iota
-	CubeTotals
+	gameTotals gameType = iota
+	cubeTotals
 )
 
-func main() {
-	args := os.Args[1:]
-	if len(args) != 1 {
-		fmt.Println("Invalid argument. Expected 'gametotals' or 'cubetotals'.")
-		os.Exit(0)
-	}
+func init() {
+	solver.Register(2, "Cube Conundrum", Solver{})
+	solver.RegisterOracle(2, Oracle{})
+}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		fmt.Printf("Error opening file: %v\n", err)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	var result int
-	switch args[0] {
-	case "gametotals":
-		result, err = processGames(file, GameTotals)
-	case "cubetotals":
-		result, err = processGames(file, CubeTotals)
-	default:
-		fmt.Println("Invalid argument. Expected 'gametotals' or 'cubetotals'.")
-		os.Exit(1)
-	}
+// Solver solves day 2.
+type Solver struct{}
 
-	if err != nil {
-		fmt.Printf("Error processing games: %v\n", err)
-		os.Exit(1)
-	}
+// Part1 sums the numbers of the games possible with the bag's cubes.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(processGames(r, gameTotals))
+}
 
-	fmt.Println(result)
+// Part2 sums the power of the fewest cubes needed for each game.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(processGames(r, cubeTotals))
 }
 
-func processGames(reader io.Reader, gameType GameType) (int, error) {
-	scanner := bufio.NewScanner(reader)
+// processGames sums the value of every game in reader per the gameType.
+func processGames(reader io.Reader, kind gameType) (int, error) {
+	lines, err := input.Lines(reader)
+	if err != nil {
+		return 0, err
+	}
+
 	result := 0
-	for scanner.Scan() {
-		game := parseGame(scanner.Text())
+	for i, line := range lines {
+		game, err := ParseGame(line)
+		if err != nil {
+			return 0, input.AtLine(err, i+1)
+		}
+
 		var gameValue int
-		var err error
-		switch gameType {
-		case GameTotals:
-			gameValue, err = calculateGameValue(game)
-		case CubeTotals:
-			gameValue, err = calculateCubePower(game)
+		switch kind {
+		case gameTotals:
+			gameValue, err = GameValue(game)
+		case cubeTotals:
+			gameValue, err = CubePower(game)
 		default:
 			return 0, fmt.Errorf("unknown game type")
 		}
@@ -93,40 +88,60 @@ func processGames(reader io.Reader, gameType GameType) (int, error) {
 	return result, nil
 }
 
-// parseGame parses a game line and returns a Game struct.
-func parseGame(line string) Game {
-	cubesMatch := cubesRegex.FindAllStringSubmatch(line, -1)
+// ParseGame parses a game line and returns a Game struct.
+func ParseGame(line string) (Game, error) {
+	gameMatch := gameRegex.FindStringSubmatchIndex(line)
+	if gameMatch == nil {
+		return Game{}, &input.ParseError{Day: 2, Column: 1, Msg: `expected "Game <number>:"`}
+	}
+	gameNum, err := input.Atoi(2, line[gameMatch[2]:gameMatch[3]], gameMatch[2]+1)
+	if err != nil {
+		return Game{}, err
+	}
 
+	// Offset cube matches by the end of the game number, so their columns
+	// point into the whole line.
+	offset := gameMatch[1]
 	var cubes []Cubes
-	for _, match := range cubesMatch {
-		numAndColor := strings.Split(match[0], " ")
+	for _, match := range cubesRegex.FindAllStringIndex(line[offset:], -1) {
+		match[0], match[1] = match[0]+offset, match[1]+offset
+		numAndColor := strings.Split(line[match[0]:match[1]], " ")
 		color := numAndColor[1]
-		num, _ := strconv.Atoi(numAndColor[0])
+		if _, ok := bagMap[color]; !ok {
+			colorColumn := match[0] + len(numAndColor[0]) + 2
+			return Game{}, &input.ParseError{Day: 2, Column: colorColumn, Msg: fmt.Sprintf("unknown cube color %q", color)}
+		}
+		num, err := input.Atoi(2, numAndColor[0], match[0]+1)
+		if err != nil {
+			return Game{}, err
+		}
 		cubes = append(cubes, Cubes{color, num})
 	}
 
-	gameNum, _ := strconv.Atoi(gameRegex.FindStringSubmatch(line)[1])
-	return Game{number: gameNum, cubes: cubes}
+	return Game{Number: gameNum, Cubes: cubes}, nil
 }
 
-func calculateGameValue(game Game) (int, error) {
-	for _, cubes := range game.cubes {
-		if cubes.number > bagMap[cubes.color] {
+// GameValue returns the game number if the game is possible with the cubes in
+// the bag, or 0 otherwise.
+func GameValue(game Game) (int, error) {
+	for _, cubes := range game.Cubes {
+		if cubes.Number > bagMap[cubes.Color] {
 			return 0, nil
 		}
 	}
 
-	return game.number, nil
+	return game.Number, nil
 }
 
-// calculateCubePower takes, for each cube color, the highest number of cubes,
-// multiplies them, and returns the result.
-func calculateCubePower(game Game) (int, error) {
+// CubePower takes, for each cube color, the highest number of cubes,
+// multiplies them, and returns the result. A color never revealed needs no
+// cubes, so its power is 0.
+func CubePower(game Game) (int, error) {
 	// Find the highest values for each color.
-	colorsMax := map[string]int{"red": math.MinInt64, "blue": math.MinInt64, "green": math.MinInt64}
-	for _, cubes := range game.cubes {
-		if cubes.number > colorsMax[cubes.color] {
-			colorsMax[cubes.color] = cubes.number
+	colorsMax := map[string]int{"red": 0, "blue": 0, "green": 0}
+	for _, cubes := range game.Cubes {
+		if cubes.Number > colorsMax[cubes.Color] {
+			colorsMax[cubes.Color] = cubes.Number
 		}
 	}
 
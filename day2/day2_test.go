@@ -1,8 +1,12 @@
-package main
+package day2
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
 )
 
 func TestGetGameTotals(t *testing.T) {
@@ -41,8 +45,11 @@ func TestGetGameTotals(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		game := parseGame(tc.input)
-		got, err := calculateGameValue(game)
+		game, err := ParseGame(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := GameValue(game)
 		if err != nil {
 			t.Error(err)
 		}
@@ -53,6 +60,33 @@ func TestGetGameTotals(t *testing.T) {
 	}
 }
 
+func TestParseGameErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"3 blue, 4 red", `day 2: line 0, column 1: expected "Game <number>:"`},
+		{"Game 99999999999999999999: 3 blue", `day 2: line 0, column 6: invalid number "99999999999999999999": value out of range`},
+		{"Game 1: 3 blue, 4 purple", `day 2: line 0, column 19: unknown cube color "purple"`},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseGame(tc.input)
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestRunBadLine(t *testing.T) {
+	buffer := bytes.NewBufferString("Game 1: 3 blue, 4 red\nGame 2: 3 blue\nGame: 1 red")
+	_, err := processGames(buffer, gameTotals)
+	expected := `day 2: line 3, column 1: expected "Game <number>:"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
+	}
+}
+
 func TestGetCubeTotals(t *testing.T) {
 	testCases := []struct {
 		input    string
@@ -78,11 +112,18 @@ func TestGetCubeTotals(t *testing.T) {
 			"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
 			36,
 		},
+		{
+			"Game 6: 3 red, 1 blue; 1 red",
+			0,
+		},
 	}
 
 	for _, tc := range testCases {
-		game := parseGame(tc.input)
-		got, err := calculateCubePower(game)
+		game, err := ParseGame(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := CubePower(game)
 		if err != nil {
 			t.Error(err)
 		}
@@ -93,28 +134,24 @@ func TestGetCubeTotals(t *testing.T) {
 	}
 }
 
-func TestRunGameTotals(t *testing.T) {
-	buffer := bytes.NewBufferString("Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green\nGame 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue\nGame 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red\nGame 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red\nGame 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green\nGame 33: 4 red; 3 red; 2 red, 1 green, 1 blue; 1 green; 1 blue, 1 red\nGame 70: 12 green, 1 blue, 4 red; 8 green, 1 red; 1 blue, 8 green; 2 green, 3 red; 5 green, 4 red; 2 blue, 12 green, 1 red")
-	got, err := processGames(buffer, GameTotals)
-	if err != nil {
-		t.Error(err)
-	}
-
-	expected := 111
-	if got != expected {
-		t.Errorf("Expected %v, but got %v", expected, got)
-	}
+func TestExamples(t *testing.T) {
+	golden.Run(t, 2)
 }
 
-func TestRunCubeTotals(t *testing.T) {
-	buffer := bytes.NewBufferString("Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green\nGame 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue\nGame 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red\nGame 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red\nGame 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green")
-	got, err := processGames(buffer, CubeTotals)
-	if err != nil {
-		t.Error(err)
-	}
+func TestOracle(t *testing.T) {
+	differential.Run(t, 2)
+}
 
-	expected := 2286
-	if got != expected {
-		t.Errorf("Expected %v, but got %v", expected, got)
+func FuzzParseGame(f *testing.F) {
+	for _, line := range strings.Split(golden.Input(f, "example1.txt"), "\n") {
+		f.Add(line)
 	}
+	f.Fuzz(func(t *testing.T, line string) {
+		game, err := ParseGame(line)
+		if err != nil {
+			return
+		}
+		GameValue(game)
+		CubePower(game)
+	})
 }
@@ -0,0 +1,66 @@
+package day2
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Oracle solves day 2 by splitting each game on its separators and keeping
+// the most cubes seen of each color. It is for checking Solver against, and
+// only solves valid inputs.
+type Oracle struct{}
+
+// Part1 sums the numbers of the games possible with the bag's cubes.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, gameTotals))
+}
+
+// Part2 sums the power of the fewest cubes needed for each game.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, cubeTotals))
+}
+
+func oracleRun(r io.Reader, kind gameType) (int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, line := range lines {
+		game, draws, _ := strings.Cut(line, ": ")
+		number, err := strconv.Atoi(strings.TrimPrefix(game, "Game "))
+		if err != nil {
+			return 0, err
+		}
+
+		most := map[string]int{}
+		for _, draw := range strings.Split(draws, "; ") {
+			for _, cubes := range strings.Split(draw, ", ") {
+				count, color, _ := strings.Cut(cubes, " ")
+				n, err := strconv.Atoi(count)
+				if err != nil {
+					return 0, err
+				}
+				if n > most[color] {
+					most[color] = n
+				}
+			}
+		}
+
+		switch kind {
+		case gameTotals:
+			if most["red"] <= 12 && most["green"] <= 13 && most["blue"] <= 14 {
+				total += number
+			}
+		case cubeTotals:
+			total += most["red"] * most["green"] * most["blue"]
+		}
+	}
+	return total, nil
+}
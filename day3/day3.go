@@ -1,15 +1,18 @@
-package main
+// Package day3 identifies part numbers (i.e. numbers adjacent to symbols) and
+// returns their sum for part 1, or the sum of gear ratios (i.e. the sum of the
+// result of multiplying two and only two numbers adjacent to an asterisk) for
+// part 2.
+package day3
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"os"
 	"regexp"
-	"strconv"
-)
 
-const filename = "day3_input.txt"
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
 var (
 	indexRegexNumber   = regexp.MustCompile(`\d+`)
@@ -17,11 +20,11 @@ var (
 	indexRegexSymbol   = regexp.MustCompile(`[^\d|^.|^\s]`)
 )
 
-type FindType int
+type findType int
 
 const (
-	Gears FindType = iota
-	PartNumbers
+	gears findType = iota
+	partNumbers
 )
 
 type MatchIndicies struct {
@@ -31,27 +34,30 @@ type MatchIndicies struct {
 }
 
 // PartNumber represents a number in the schematic.
-// `number` is the literal number and `indicies` are the indicies of each
+// `Number` is the literal number and `Indices` are the indices of each
 // digit, including +1 on either side to aid in finding adjacent symbols.
 type PartNumber struct {
-	number  int
-	indices []int
+	Number  int
+	Indices []int
 }
 
 // NewPartNumber creates a new PartNumber.
-func NewPartNumber(numStr string, start int, end int) PartNumber {
-	number, _ := strconv.Atoi(numStr)
+func NewPartNumber(numStr string, start int, end int) (PartNumber, error) {
+	number, err := input.Atoi(3, numStr, start+1)
+	if err != nil {
+		return PartNumber{}, err
+	}
 	indicies := makeRange(start-1, end+1) // Expand range to include adjacent indices.
 	return PartNumber{
-		number:  number,
-		indices: indicies,
-	}
+		Number:  number,
+		Indices: indicies,
+	}, nil
 }
 
-// isAdjacent checks if a PartNumber is adjacent to a given index.
+// IsAdjacent checks if a PartNumber is adjacent to a given index.
 // The adjacent check includes numbers that are diagonal from an index.
-func (pn PartNumber) isAdjacent(index int) bool {
-	for _, number := range pn.indices {
+func (pn PartNumber) IsAdjacent(index int) bool {
+	for _, number := range pn.Indices {
 		if number == index {
 			return true
 		}
@@ -60,35 +66,22 @@ func (pn PartNumber) isAdjacent(index int) bool {
 	return false
 }
 
-// main is the program entrypoint and accepts two args: 'partnumbers' or 'gears'.
-// This program identifies part numbers (i.e. numbers adjacent to symbols)
-// returns their sum with the 'partnumbers' argument, or the sum of gear ratios
-// (i.e. the sum of the result of multiplying two and only two numbers adjacent
-// to asterisk) with the 'gears' argument.
-func main() {
-	args := os.Args
-	file, err := os.Open(filename)
-	if err != nil {
-		fmt.Printf("Error: %v", err)
-	}
-	defer file.Close()
+func init() {
+	solver.Register(3, "Gear Ratios", Solver{})
+	solver.RegisterOracle(3, Oracle{})
+}
 
-	switch args[1] {
-	case "partnumbers":
-		result, err := readSchematic(file, PartNumbers)
-		if err != nil {
-			fmt.Printf("Error: %v", err)
-		}
-		fmt.Println(result)
-	case "gears":
-		result, err := readSchematic(file, Gears)
-		if err != nil {
-			fmt.Printf("Error: %v", err)
-		}
-		fmt.Println(result)
-	default:
-		fmt.Println("Expected argument to be 'partnumbers' or 'gears'")
-	}
+// Solver solves day 3.
+type Solver struct{}
+
+// Part1 sums the part numbers adjacent to a symbol.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(readSchematic(r, partNumbers))
+}
+
+// Part2 sums the gear ratios.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(readSchematic(r, gears))
 }
 
 // Generate a range between two numbers, excluding the last number.
@@ -100,98 +93,156 @@ func makeRange(start, end int) []int {
 	return result
 }
 
-// Get the relevant symbol matches for a line.
-func getSymbolMatches(findType FindType, line string) [][]int {
-	switch findType {
-	case PartNumbers:
+// Get the relevant symbol matches for a line, or none for an invalid
+// findType, which readSchematic rejects.
+func getSymbolMatches(find findType, line string) [][]int {
+	switch find {
+	case partNumbers:
 		return indexRegexSymbol.FindAllStringIndex(line, -1)
-	case Gears:
+	case gears:
 		return indexRegexAsterisk.FindAllStringIndex(line, -1)
 	default:
-		fmt.Println("Invalid findType: must be PartNumbers or Gears.")
 		return nil
 	}
 }
 
-// calculateSum adds up all the values of a []PartNumber per the rules of the FindType.
-// PartNumbers adjacent to symbols have their numerical value added to the total sum.
-// Gears have their two part numbers multiplied then added to the total sum.
-func calculateSum(findType FindType, candidates []PartNumber, index int) int {
+// gearRatio returns the product of the two part numbers adjacent to a gear at
+// index, or 0 if there aren't exactly two.
+func gearRatio(candidates []PartNumber, index int) int {
 	var adjacentNumbers []int
 	for _, candidate := range candidates {
-		if candidate.isAdjacent(index) {
-			adjacentNumbers = append(adjacentNumbers, candidate.number)
+		if candidate.IsAdjacent(index) {
+			adjacentNumbers = append(adjacentNumbers, candidate.Number)
 		}
 	}
 
-	var sum int
-	switch findType {
-	case PartNumbers:
-		for _, number := range adjacentNumbers {
-			sum += number
+	if len(adjacentNumbers) != 2 {
+		return 0
+	}
+	return adjacentNumbers[0] * adjacentNumbers[1]
+}
+
+// readSchematic reads through a schematic and adds up numbers per the rules
+// for part numbers and gears. Every row of a schematic is the same length.
+func readSchematic(reader io.Reader, find findType) (int, error) {
+	grid, err := input.Grid(3, reader)
+	if err != nil {
+		return 0, err
+	}
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+
+	switch find {
+	case partNumbers:
+		return partNumberSum(lines)
+	case gears:
+		return gearRatioSum(lines)
+	default:
+		return 0, fmt.Errorf("invalid findType: must be partNumbers or gears")
+	}
+}
+
+// partNumberSum adds up every number adjacent to a symbol. A number next to
+// more than one symbol is still counted once.
+func partNumberSum(lines []string) (int, error) {
+	total := 0
+	for lineIndex, line := range lines {
+		candidates, err := LineCandidates(line)
+		if err != nil {
+			return 0, input.AtLine(err, lineIndex+1)
 		}
-	case Gears:
-		if len(adjacentNumbers) == 2 {
-			sum += adjacentNumbers[0] * adjacentNumbers[1]
+
+		for _, candidate := range candidates {
+			if hasAdjacentSymbol(candidate, lineIndex, lines) {
+				total += candidate.Number
+			}
 		}
-	default:
-		fmt.Println("Invalid findType: must be PartNumbers or Gears.")
-		return 0
 	}
 
-	return sum
+	return total, nil
 }
 
-// readSchematic reads through a schematic and adds up numbers per the rules
-// for part numbers and gears.
-func readSchematic(reader io.Reader, findType FindType) (int, error) {
-	scanner := bufio.NewScanner(reader)
-	lines := []string{}
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// hasAdjacentSymbol checks the line above, below, and the same line as a
+// PartNumber for a symbol next to it.
+func hasAdjacentSymbol(candidate PartNumber, lineIndex int, lines []string) bool {
+	for i := lineIndex - 1; i <= lineIndex+1; i++ {
+		if i < 0 || i >= len(lines) {
+			continue
+		}
+		for _, match := range getSymbolMatches(partNumbers, lines[i]) {
+			if candidate.IsAdjacent(match[0]) {
+				return true
+			}
+		}
 	}
 
-	// Process all symbols on all lines and get the sum per the findType.
+	return false
+}
+
+// gearRatioSum adds up the gear ratio of every asterisk.
+func gearRatioSum(lines []string) (int, error) {
 	total := 0
 	for lineIndex, line := range lines {
-		lineMatchIndices := getSymbolMatches(PartNumbers, line)
-		for _, match := range lineMatchIndices {
-			candidates := getAllCandidates(lineIndex, lines)
-			total += calculateSum(findType, candidates, match[0])
+		for _, match := range getSymbolMatches(gears, line) {
+			candidates, err := getAllCandidates(lineIndex, lines)
+			if err != nil {
+				return 0, err
+			}
+			total += gearRatio(candidates, match[0])
 		}
 	}
 
 	return total, nil
 }
 
-// makeLineCandidates creates a []PartNumber of every PartNumber on a line.
-func makeLineCandidates(line string) []PartNumber {
+// LineCandidates creates a []PartNumber of every PartNumber on a line.
+func LineCandidates(line string) ([]PartNumber, error) {
 	matchNumbers := indexRegexNumber.FindAllString(line, -1)      // The number itself.
 	matchIndices := indexRegexNumber.FindAllStringIndex(line, -1) // The number's start/end index.
 	var candidates []PartNumber
 	for idx, numStr := range matchNumbers {
-		partNumber := NewPartNumber(numStr, matchIndices[idx][0], matchIndices[idx][1])
+		partNumber, err := NewPartNumber(numStr, matchIndices[idx][0], matchIndices[idx][1])
+		if err != nil {
+			return nil, err
+		}
 		candidates = append(candidates, partNumber)
 	}
-	return candidates
+	return candidates, nil
 }
 
 // getAllCandidates creates a []PartNumber of every number candidate
 // Look for a matching index on the line above, below, and the same line as
 // the symbol.
-func getAllCandidates(baseLineNumber int, lines []string) []PartNumber {
+func getAllCandidates(baseLineNumber int, lines []string) ([]PartNumber, error) {
 	var candidates []PartNumber
+	addLine := func(lineNumber int) error {
+		lineCandidates, err := LineCandidates(lines[lineNumber])
+		if err != nil {
+			return input.AtLine(err, lineNumber+1)
+		}
+		candidates = append(candidates, lineCandidates...)
+		return nil
+	}
+
 	// Above line
 	if baseLineNumber > 0 {
-		candidates = append(candidates, makeLineCandidates(lines[baseLineNumber-1])...)
+		if err := addLine(baseLineNumber - 1); err != nil {
+			return nil, err
+		}
 	}
 
 	// Below line
 	if baseLineNumber < len(lines)-1 {
-		candidates = append(candidates, makeLineCandidates(lines[baseLineNumber+1])...)
+		if err := addLine(baseLineNumber + 1); err != nil {
+			return nil, err
+		}
 	}
 
 	// Same line
-	candidates = append(candidates, makeLineCandidates(lines[baseLineNumber])...)
-	return candidates
+	if err := addLine(baseLineNumber); err != nil {
+		return nil, err
+	}
+	return candidates, nil
 }
@@ -1,34 +1,35 @@
-package main
+package day3
 
 import (
 	"bytes"
+	"strings"
 	"testing"
-)
 
-const testSchematic = "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+..58\n..592.....\n......755.\n...$.*....\n.664.598.."
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+)
 
-func TestReadSchematicPartOne(t *testing.T) {
-	buffer := bytes.NewBufferString(testSchematic)
-	got, err := readSchematic(buffer, PartNumbers)
-	if err != nil {
-		t.Error(err)
+func TestReadSchematicBadNumber(t *testing.T) {
+	buffer := bytes.NewBufferString("467..114..............\n...*..................\n..99999999999999999999")
+	_, err := readSchematic(buffer, partNumbers)
+	expected := `day 3: line 3, column 3: invalid number "99999999999999999999": value out of range`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
 	}
+}
 
-	expected := 4361
-	if got != expected {
-		t.Errorf("Expected %d, but got %d", expected, got)
-	}
+func TestExamples(t *testing.T) {
+	golden.Run(t, 3)
 }
 
-func TestReadSchematicPartTwo(t *testing.T) {
-	buffer := bytes.NewBufferString(testSchematic)
-	got, err := readSchematic(buffer, Gears)
-	if err != nil {
-		t.Error(err)
-	}
+func TestOracle(t *testing.T) {
+	differential.Run(t, 3)
+}
 
-	expected := 467835
-	if got != expected {
-		t.Errorf("Expected %d, but got %d", expected, got)
-	}
+func FuzzReadSchematic(f *testing.F) {
+	f.Add(golden.Input(f, "example1.txt"))
+	f.Fuzz(func(t *testing.T, schematic string) {
+		readSchematic(strings.NewReader(schematic), partNumbers)
+		readSchematic(strings.NewReader(schematic), gears)
+	})
 }
@@ -0,0 +1,74 @@
+package day3
+
+import (
+	"context"
+	"io"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Oracle solves day 3 by looking at every cell around every number. It is for
+// checking Solver against, and only solves valid inputs.
+type Oracle struct{}
+
+// Part1 sums the part numbers adjacent to a symbol.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, partNumbers))
+}
+
+// Part2 sums the gear ratios.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, gears))
+}
+
+func oracleRun(r io.Reader, find findType) (int, error) {
+	lines, err := input.Grid(3, r)
+	if err != nil {
+		return 0, err
+	}
+
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+
+	total := 0
+	gearNumbers := map[[2]int][]int{}
+	for row, line := range lines {
+		for start := 0; start < len(line); start++ {
+			if !isDigit(line[start]) || (start > 0 && isDigit(line[start-1])) {
+				continue
+			}
+			end, number := start, 0
+			for ; end < len(line) && isDigit(line[end]); end++ {
+				number = 10*number + int(line[end]-'0')
+			}
+
+			isPart := false
+			for y := row - 1; y <= row+1; y++ {
+				for x := start - 1; x <= end; x++ {
+					if y < 0 || y >= len(lines) || x < 0 || x >= len(lines[y]) {
+						continue
+					}
+					c := lines[y][x]
+					if !isDigit(c) && c != '.' {
+						isPart = true
+					}
+					if c == '*' {
+						gearNumbers[[2]int{y, x}] = append(gearNumbers[[2]int{y, x}], number)
+					}
+				}
+			}
+			if isPart && find == partNumbers {
+				total += number
+			}
+		}
+	}
+
+	if find == gears {
+		for _, numbers := range gearNumbers {
+			if len(numbers) == 2 {
+				total += numbers[0] * numbers[1]
+			}
+		}
+	}
+	return total, nil
+}
@@ -1,33 +1,34 @@
-package main
+// Package day4 scores scratchcards: total points for part 1, or the total
+// number of cards after winning copies for part 2.
+package day4
 
 import (
-	"bufio"
-	"fmt"
+	"context"
 	"io"
-	"os"
-	"regexp"
-	"strconv"
 	"strings"
-)
 
-const filename = "day4_input.txt"
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
-type FindType int
+type findType int
 
 const (
-	Points FindType = iota
-	Copies
+	points findType = iota
+	copies
 )
 
+// Card is a scratchcard and the number of copies of it held.
 type Card struct {
-	cardNo         int
-	copies         int
-	matches        int
-	winningNumbers []int
-	yourNumbers    []int
+	CardNo         int
+	Copies         int
+	Matches        int
+	WinningNumbers []int
+	YourNumbers    []int
 	deck           *CardDeck
 }
 
+// CardDeck holds every Card by its card number.
 type CardDeck struct {
 	cards map[int]Card
 }
@@ -35,48 +36,57 @@ type CardDeck struct {
 // Global state. :(
 // var cards = make(map[int]Card)
 
-func NewCard(deck *CardDeck, line string, index int) Card {
+// NewCard parses a card line. index is the card's zero-based position in the
+// input.
+func NewCard(deck *CardDeck, line string, index int) (Card, error) {
 	// Parse the line into a usable format.
-	numberRegex := regexp.MustCompile(`\d+`)
 	colonIndex := strings.Index(line, ":")
-	lineWithoutCardNo := line[colonIndex+1:]
-	winnersAndYourCards := strings.Split(lineWithoutCardNo, "|")
-	winnerStrMatches := numberRegex.FindAllString(winnersAndYourCards[0], -1)
-	yourStrMatches := numberRegex.FindAllString(winnersAndYourCards[1], -1)
-
-	// Helper func to turn a []string{"1", "2", "3"} int []int{1, 2, 3}.
-	makeNumbers := func(numStr []string) []int {
-		nums := make([]int, len(numStr))
-		for index, numStr := range numStr {
-			parsedNum, _ := strconv.Atoi(numStr)
-			nums[index] = parsedNum
-		}
-		return nums
+	if colonIndex < 0 {
+		return Card{}, &input.ParseError{Day: 4, Msg: `expected "Card <number>:"`}
+	}
+	pipeIndex := strings.Index(line, "|")
+	if pipeIndex < colonIndex {
+		return Card{}, &input.ParseError{Day: 4, Msg: `expected "|" between the winning numbers and your numbers`}
 	}
 
-	winners := makeNumbers(winnerStrMatches)
-	yourNumbers := makeNumbers(yourStrMatches)
+	// Helper func to turn the numbers in line[start:end] into an []int, e.g.
+	// " 1 2 3 " into []int{1, 2, 3}. The numbers are padded to where they
+	// are in line, so an error has their column.
+	makeNumbers := func(start int, end int) ([]int, error) {
+		return input.Ints(4, strings.Repeat(" ", start)+line[start:end])
+	}
+
+	winners, err := makeNumbers(colonIndex+1, pipeIndex)
+	if err != nil {
+		return Card{}, err
+	}
+	yourNumbers, err := makeNumbers(pipeIndex+1, len(line))
+	if err != nil {
+		return Card{}, err
+	}
 
 	card := Card{
-		cardNo:         index + 1,
-		winningNumbers: winners,
-		yourNumbers:    yourNumbers,
-		copies:         1,
+		CardNo:         index + 1,
+		WinningNumbers: winners,
+		YourNumbers:    yourNumbers,
+		Copies:         1,
 		deck:           deck,
 	}
-	card.matches = card.getMatches()
+	card.Matches = card.getMatches()
 
-	return card
+	return card, nil
 }
 
+// NewCardDeck returns an empty CardDeck.
 func NewCardDeck() *CardDeck {
 	return &CardDeck{
 		cards: make(map[int]Card),
 	}
 }
 
-func (cd *CardDeck) addCard(card Card) {
-	cd.cards[card.cardNo] = card
+// AddCard adds a card to the deck.
+func (cd *CardDeck) AddCard(card Card) {
+	cd.cards[card.CardNo] = card
 }
 
 // contains returns true if a slice contains a given number.
@@ -89,19 +99,19 @@ func (c Card) contains(slice []int, number int) bool {
 	return false
 }
 
-// getPoints returns the total points for a card (for part 1).
-func (c Card) getPoints() int {
-	if c.matches == 0 {
+// Points returns the total points for a card (for part 1).
+func (c Card) Points() int {
+	if c.Matches == 0 {
 		return 0
 	}
 
-	if c.matches == 1 {
+	if c.Matches == 1 {
 		return 1
 	}
 
 	result := 1
-	if c.matches >= 1 {
-		for i := 1; i < c.matches; i++ {
+	if c.Matches >= 1 {
+		for i := 1; i < c.Matches; i++ {
 			result *= 2
 		}
 	}
@@ -111,8 +121,8 @@ func (c Card) getPoints() int {
 // getMatches returns the number of matching numbers on a card.
 func (c Card) getMatches() int {
 	var matches int
-	for _, v := range c.winningNumbers {
-		if c.contains(c.yourNumbers, v) {
+	for _, v := range c.WinningNumbers {
+		if c.contains(c.YourNumbers, v) {
 			matches += 1
 		}
 	}
@@ -124,87 +134,91 @@ func (c Card) getMatches() int {
 // of cards 11, 12, 13, 14, and 15, if you had TWO copies of card 10, you'd do this TWICE,
 // ending up with more copies of 11-15.
 func (c Card) addCopies() {
-	start := c.cardNo + 1
-	end := c.cardNo + c.matches
+	start := c.CardNo + 1
+	end := c.CardNo + c.Matches
 	for subsequentIndex := start; subsequentIndex <= end; subsequentIndex++ {
 		// Don't add copies of cards beyond the limit.
 		if subsequentIndex > len(c.deck.cards) {
 			continue
 		}
 		card := c.deck.cards[subsequentIndex]
-		card.copies += c.copies
+		card.Copies += c.Copies
 		c.deck.cards[subsequentIndex] = card
 	}
 }
 
-func main() {
-	args := os.Args
-	if len(args) != 2 {
-		fmt.Println("Please provide the parameter 'points' or 'copies'.")
-		return
-	}
+func init() {
+	solver.Register(4, "Scratchcards", Solver{})
+	solver.RegisterOracle(4, Oracle{})
+}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
+// Solver solves day 4.
+type Solver struct{}
 
-	switch os.Args[1] {
-	case "points":
-		result := run(file, Points)
-		fmt.Println(result)
-	case "copies":
-		result := run(file, Copies)
-		fmt.Println("copies:", result)
-	}
+// Part1 sums the points of every card.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(r, points))
+}
+
+// Part2 counts the cards, including every copy won.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(r, copies))
 }
 
-// loadCardsFromFile reads file, creates one card per line, and adds them to deck.
-func loadCardsFromFile(deck *CardDeck, file io.Reader) {
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// LoadCards reads file, creates one card per line, and adds them to deck.
+func LoadCards(deck *CardDeck, file io.Reader) error {
+	lines, err := input.Lines(file)
+	if err != nil {
+		return err
 	}
 
 	for idx, line := range lines {
-		card := NewCard(deck, line, idx)
-		deck.addCard(card)
+		card, err := NewCard(deck, line, idx)
+		if err != nil {
+			return input.AtLine(err, idx+1)
+		}
+		deck.AddCard(card)
 	}
+	return nil
 }
 
-func (cd *CardDeck) getTotalPoints() int {
+// TotalPoints returns the points of every card in the deck (for part 1).
+func (cd *CardDeck) TotalPoints() int {
 	var total int
 	for _, card := range cd.cards {
-		total += card.getPoints()
+		total += card.Points()
 	}
 	return total
 }
 
-func (cd *CardDeck) getTotalcopies() int {
+// TotalCopies wins copies of cards and returns the total number of cards (for
+// part 2).
+func (cd *CardDeck) TotalCopies() int {
 	var total int
 	for i := 0; i < len(cd.cards); i++ {
 		cd.cards[i].addCopies()
 	}
 
 	for _, card := range cd.cards {
-		total += card.copies
+		total += card.Copies
 	}
 
 	return total
 }
 
-func run(file io.Reader, findType FindType) int {
+// run returns the total points or copies in file per the findType.
+func run(file io.Reader, find findType) (int, error) {
 	deck := NewCardDeck()
-	loadCardsFromFile(deck, file)
+	if err := LoadCards(deck, file); err != nil {
+		return 0, err
+	}
 
-	switch findType {
-	case Points:
-		return deck.getTotalPoints()
-	case Copies:
-		return deck.getTotalcopies()
+	switch find {
+	case points:
+		return deck.TotalPoints(), nil
+	case copies:
+		return deck.TotalCopies(), nil
 	default:
-		return 0
+		return 0, nil
 	}
 }
@@ -1,13 +1,16 @@
-package main
+package day4
 
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
 )
 
 const (
-	testCards      = "Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53\nCard 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19\nCard 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1\nCard 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83\nCard 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36\nCard 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11"
 	singleTestCard = "Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53"
 )
 
@@ -15,42 +18,73 @@ func TestNewCard(t *testing.T) {
 	deck := NewCardDeck()
 	expected := Card{
 		deck:           deck,
-		cardNo:         1,
-		copies:         1,
-		matches:        4,
-		winningNumbers: []int{41, 48, 83, 86, 17},
-		yourNumbers:    []int{83, 86, 6, 31, 17, 9, 48, 53},
+		CardNo:         1,
+		Copies:         1,
+		Matches:        4,
+		WinningNumbers: []int{41, 48, 83, 86, 17},
+		YourNumbers:    []int{83, 86, 6, 31, 17, 9, 48, 53},
+	}
+	got, err := NewCard(deck, singleTestCard, 0)
+	if err != nil {
+		t.Fatal(err)
 	}
-	got := NewCard(deck, singleTestCard, 0)
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("Expected %v, but got %v", expected, got)
 	}
 }
 
+func TestNewCardErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Card 1 41 48 | 83 86", `day 4: line 0: expected "Card <number>:"`},
+		{"Card 1: 41 48 83 86", `day 4: line 0: expected "|" between the winning numbers and your numbers`},
+		{"Card 1: 41 48 | 83 99999999999999999999", `day 4: line 0, column 20: invalid number "99999999999999999999": value out of range`},
+	}
+
+	for _, tc := range testCases {
+		_, err := NewCard(NewCardDeck(), tc.input, 0)
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
 func TestCardGetPoints(t *testing.T) {
 	deck := NewCardDeck()
-	card := NewCard(deck, singleTestCard, 0)
+	card, err := NewCard(deck, singleTestCard, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := 8
-	got := card.getPoints()
+	got := card.Points()
 	if got != expected {
 		t.Fatalf("Expected %d, but got %d", expected, got)
 	}
 }
 
-func TestRun(t *testing.T) {
-	buffer := bytes.NewBufferString(testCards)
-	got := run(buffer, Points)
-	expected := 13
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
+func TestRunBadLine(t *testing.T) {
+	buffer := bytes.NewBufferString(singleTestCard + "\nCard 2: 13 32 20 16 61")
+	_, err := run(buffer, points)
+	expected := `day 4: line 2: expected "|" between the winning numbers and your numbers`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
 	}
 }
 
-func TestRunCopies(t *testing.T) {
-	buffer := bytes.NewBufferString(testCards)
-	got := run(buffer, Copies)
-	expected := 30
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
-	}
+func TestExamples(t *testing.T) {
+	golden.Run(t, 4)
+}
+
+func TestOracle(t *testing.T) {
+	differential.Run(t, 4)
+}
+
+func FuzzRun(f *testing.F) {
+	f.Add(golden.Input(f, "example1.txt"))
+	f.Fuzz(func(t *testing.T, cards string) {
+		run(strings.NewReader(cards), points)
+		run(strings.NewReader(cards), copies)
+	})
 }
@@ -0,0 +1,71 @@
+package day4
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Oracle solves day 4 by scratching every card, including every copy won,
+// one at a time. It is for checking Solver against, and only solves valid
+// inputs.
+type Oracle struct{}
+
+// Part1 sums the points of every card.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, points))
+}
+
+// Part2 counts the cards, including every copy won.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, copies))
+}
+
+func oracleRun(r io.Reader, find findType) (int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return 0, err
+	}
+
+	matches := make([]int, len(lines))
+	for i, line := range lines {
+		_, numbers, _ := strings.Cut(line, ":")
+		winning, yours, _ := strings.Cut(numbers, "|")
+		for _, w := range strings.Fields(winning) {
+			for _, y := range strings.Fields(yours) {
+				if w == y {
+					matches[i]++
+				}
+			}
+		}
+	}
+
+	total := 0
+	switch find {
+	case points:
+		for _, m := range matches {
+			if m > 0 {
+				total += 1 << (m - 1)
+			}
+		}
+	case copies:
+		// Every card in the pile is scratched, and wins copies of the cards
+		// after it, which go on the pile.
+		pile := make([]int, len(lines))
+		for i := range pile {
+			pile[i] = i
+		}
+		for len(pile) > 0 {
+			card := pile[len(pile)-1]
+			pile = pile[:len(pile)-1]
+			total++
+			for won := card + 1; won <= card+matches[card] && won < len(lines); won++ {
+				pile = append(pile, won)
+			}
+		}
+	}
+	return total, nil
+}
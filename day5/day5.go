@@ -1,18 +1,16 @@
-package main
+// Package day5 finds the lowest location for the seeds in an almanac.
+package day5
 
 import (
-	"bufio"
-	"errors"
-	"flag"
-	"fmt"
+	"context"
 	"io"
 	"math"
-	"os"
 	"regexp"
-	"strconv"
-)
+	"strings"
 
-const filename = "day5_input.txt"
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
 var (
 	regexSeeds         = regexp.MustCompile(`(\d+)`)
@@ -27,140 +25,174 @@ var (
 	}
 )
 
-type FindType int
+type puzzlePart int
 
 const (
-	Part1 FindType = iota
-	Part2
+	part1 puzzlePart = iota
+	part2
 )
 
 // Mapping is an individual map of the source to destination.
 type Mapping struct {
-	name             string
-	destinationStart int
-	sourceStart      int
-	range_           int
+	Name             string
+	DestinationStart int
+	SourceStart      int
+	Range            int
 }
 
 // ConversionMap is a collection of transformation mappings, such as seed-to-soil.
 type ConversionMap []Mapping
 
+// Almanac is the list of seeds and the maps that convert them to locations.
+type Almanac struct {
+	Seeds []int
+	Maps  ConversionMap
+}
+
 // SourceRange represents a range of numbers for use with the source of a map.
 type SourceRange struct {
-	start  int
-	end    int
-	range_ int
+	Start int
+	End   int
+	Range int
+}
+
+func init() {
+	solver.Register(5, "If You Give A Seed A Fertilizer", Solver{})
+	solver.RegisterOracle(5, Oracle{})
+}
+
+// Solver solves day 5.
+type Solver struct{}
+
+// Part1 returns the lowest location of the listed seeds.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(r, part1))
+}
+
+// Part2 returns the lowest location of the listed seed ranges.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(r, part2))
 }
 
-func main() {
-	part := flag.Int("part", 2, "The puzzle part to run (i.e. either 1 or 2)")
-	flag.Parse()
-	if *part < 1 || *part > 2 {
-		fmt.Printf("Expected argument to -part: '1' or '2'.")
-		os.Exit(1)
+// run() is the entrypoint to the program and contains the main logic.
+func run(file io.Reader, part puzzlePart) (int, error) {
+	almanac, err := ParseAlmanac(file)
+	if err != nil {
+		return 0, err
 	}
 
-	var puzzlePart FindType
-	switch *part {
-	case 1:
-		puzzlePart = Part1
-	case 2:
-		puzzlePart = Part2
+	switch part {
+	case part1:
+		return almanac.LowestLocation(), nil
+	case part2:
+		return almanac.LowestRangeLocation(), nil
 	}
 
-	file, err := os.Open(filename)
+	return math.MaxInt64, nil
+}
+
+// ParseAlmanac() reads the seeds and conversion maps from an almanac.
+func ParseAlmanac(file io.Reader) (Almanac, error) {
+	paragraphs, err := input.Paragraphs(file)
 	if err != nil {
-		panic(err)
+		return Almanac{}, err
+	}
+	if len(paragraphs) == 0 {
+		return Almanac{}, &input.ParseError{Day: 5, Line: 1, Msg: "can't find seed"}
 	}
 
-	result, err := run(file, puzzlePart)
+	seeds, err := getSeeds(paragraphs[0].Lines)
 	if err != nil {
-		panic(err)
+		return Almanac{}, input.AtLine(err, paragraphs[0].Line)
 	}
-	fmt.Println(result)
-}
 
-// run() is the entrypoint to the program and contains the main logic.
-func run(file io.Reader, puzzlePart FindType) (int, error) {
-	lines := getLines(file)
-	maps := getMaps(lines, ConversionMap{}, "")
-	locations := []int{}
-	lowest := math.MaxInt64
-
-	switch puzzlePart {
-	case Part1:
-		seeds, err := getSeeds(lines)
+	// Every other paragraph is one conversion map.
+	maps := ConversionMap{}
+	for _, paragraph := range paragraphs[1:] {
+		maps, err = getMaps(paragraph.Lines, paragraph.Line, maps, "")
 		if err != nil {
-			return 0, err
-		}
-		for _, seed := range seeds {
-			locations = append(locations, getSeedLocation(seed, conversionMapNames, maps))
+			return Almanac{}, err
 		}
-		for _, num := range locations {
-			if num < lowest {
-				lowest = num
-			}
-		}
-	case Part2:
-		matches := regexSeeds.FindAllString(lines[0], -1)
-		if matches == nil {
-			return 0, errors.New("can't find seed")
-		}
-		seedRanges := getSeedRanges(matches, []SourceRange{})
-		locations := getFinalLocations(seedRanges, conversionMapNames, maps)
-		for _, location := range locations {
-			if location.end < lowest {
-				lowest = location.start
-			}
+	}
+
+	return Almanac{Seeds: seeds, Maps: maps}, nil
+}
+
+// SeedLocation() gets the final location of a seed.
+func (a Almanac) SeedLocation(seed int) int {
+	return getSeedLocation(seed, conversionMapNames, a.Maps)
+}
+
+// LowestLocation() returns the lowest location of the seeds in Part 1.
+func (a Almanac) LowestLocation() int {
+	lowest := math.MaxInt64
+	for _, seed := range a.Seeds {
+		if location := a.SeedLocation(seed); location < lowest {
+			lowest = location
 		}
 	}
 
-	return lowest, nil
+	return lowest
 }
 
-// Shared code between Part 1 and Part 2.
-// getLines() gets the lines from a file as []string.
-func getLines(file io.Reader) []string {
-	scanner := bufio.NewScanner(file)
-	lines := []string{}
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// LowestRangeLocation() returns the lowest location of the seeds in Part 2,
+// where the seeds are start/range pairs.
+func (a Almanac) LowestRangeLocation() int {
+	lowest := math.MaxInt64
+	seedRanges := getSeedRanges(a.Seeds, []SourceRange{})
+	locations := getFinalLocations(seedRanges, conversionMapNames, a.Maps)
+	for _, location := range locations {
+		if location.Start < lowest {
+			lowest = location.Start
+		}
 	}
-	return lines
+
+	return lowest
 }
 
+// Shared code between Part 1 and Part 2.
 // getMaps returns a Mappings representing all the destination-source maps.
-func getMaps(lines []string, acc ConversionMap, lastMapName string) ConversionMap {
+// lineNo is the line number of lines[0], for reporting errors. Blank lines
+// are skipped.
+func getMaps(lines []string, lineNo int, acc ConversionMap, lastMapName string) (ConversionMap, error) {
 	if len(lines) == 0 {
-		return acc
+		return acc, nil
 	}
 
 	regexpMapName := regexp.MustCompile(`(\w+.*) (?:map:)`)
 	regexpMap := regexp.MustCompile(`^(\d+)\s+(\d+)\s+(\d+)$`)
 	line := lines[0]
 	matchMapName := regexpMapName.FindStringSubmatch(line)
-	matchMap := regexpMap.FindStringSubmatch(line)
+	matchMap := regexpMap.FindStringSubmatchIndex(line)
 
 	if matchMapName != nil {
 		lastMapName = matchMapName[1:][0]
 	} else if matchMap != nil {
+		if lastMapName == "" {
+			return nil, &input.ParseError{Day: 5, Line: lineNo, Column: 1, Msg: "expected a map name before its mappings"}
+		}
 		slice := []int{}
-		for _, numStr := range matchMap[1:] {
-			num, _ := strconv.Atoi(numStr)
+		for i := 2; i < len(matchMap); i += 2 {
+			num, err := input.Atoi(5, line[matchMap[i]:matchMap[i+1]], matchMap[i]+1)
+			if err != nil {
+				return nil, input.AtLine(err, lineNo)
+			}
 			slice = append(slice, num)
 		}
 		mapping := NewMapping(lastMapName, slice)
 		acc = append(acc, mapping)
+	} else if strings.TrimSpace(line) != "" {
+		return nil, &input.ParseError{Day: 5, Line: lineNo, Column: 1, Msg: `expected "<name> map:" or three numbers`}
 	}
-	return getMaps(lines[1:], acc, lastMapName)
+	return getMaps(lines[1:], lineNo+1, acc, lastMapName)
 }
 
 // Part 2 code.
 
-// convertRanges() is a recursive function to process a group of map directives and
+// ConvertRanges() is a recursive function to process a group of map directives and
 // returns the a []sourceRange of the final locations, whether a source was
 // mapped to a new destination, or whether it fell through in Part 2.
-func (tm ConversionMap) convertRanges(unProcessed []SourceRange, converted []SourceRange) []SourceRange {
+func (tm ConversionMap) ConvertRanges(unProcessed []SourceRange, converted []SourceRange) []SourceRange {
 	if len(tm) == 0 {
 		return append(unProcessed, converted...)
 	}
@@ -169,39 +201,39 @@ func (tm ConversionMap) convertRanges(unProcessed []SourceRange, converted []Sou
 	fellThrough := []SourceRange{}
 
 	for _, seedRange := range unProcessed {
-		mapSourceEnd := mapPart.sourceStart + mapPart.range_ - 1
+		mapSourceEnd := mapPart.SourceStart + mapPart.Range - 1
 
 		// Pass through ranges lacking an intersection with this map part's source.
-		if seedRange.end < mapPart.sourceStart || seedRange.start > mapSourceEnd {
+		if seedRange.End < mapPart.SourceStart || seedRange.Start > mapSourceEnd {
 			fellThrough = append(fellThrough, seedRange)
 			continue
 		}
 
 		// Find the start and end of the intersection so we know the range that
 		// must shift via the map source.
-		intersectionStart := max(seedRange.start, mapPart.sourceStart)
-		intersectionEnd := min(seedRange.end, mapSourceEnd)
+		intersectionStart := max(seedRange.Start, mapPart.SourceStart)
+		intersectionEnd := min(seedRange.End, mapSourceEnd)
 
 		// If numbers are above or below the source's intersection with the range,
 		// then pass them through to the next map.
-		if intersectionStart > seedRange.start {
-			below := SourceRange{start: seedRange.start, end: intersectionStart - 1}
+		if intersectionStart > seedRange.Start {
+			below := SourceRange{Start: seedRange.Start, End: intersectionStart - 1}
 			fellThrough = append(fellThrough, below)
 		}
 
-		if intersectionEnd < seedRange.end {
-			above := SourceRange{start: intersectionEnd + 1, end: seedRange.end}
+		if intersectionEnd < seedRange.End {
+			above := SourceRange{Start: intersectionEnd + 1, End: seedRange.End}
 			fellThrough = append(fellThrough, above)
 		}
 
 		// Calculate the shifted intersection range based on the map part's destination.
-		offset := intersectionStart - mapPart.sourceStart
+		offset := intersectionStart - mapPart.SourceStart
 		intersectionLength := intersectionEnd - intersectionStart
-		shiftedIntersection := SourceRange{start: mapPart.destinationStart + offset, end: mapPart.destinationStart + offset + intersectionLength}
+		shiftedIntersection := SourceRange{Start: mapPart.DestinationStart + offset, End: mapPart.DestinationStart + offset + intersectionLength}
 		converted = append(converted, shiftedIntersection)
 	}
 
-	return tm[1:].convertRanges(fellThrough, converted)
+	return tm[1:].ConvertRanges(fellThrough, converted)
 }
 
 // getFinalLocations() is a recursive function that processes map collections
@@ -215,29 +247,27 @@ func getFinalLocations(seeds []SourceRange, conversionMaps []string, maps Conver
 	// Gather the maps components (e.g. all `seed-to-soil` maps) for this round.
 	conversionMap := ConversionMap{}
 	for _, map_ := range maps {
-		if map_.name == conversionMaps[0] {
+		if map_.Name == conversionMaps[0] {
 			conversionMap = append(conversionMap, map_)
 		}
 	}
 
-	seedRanges := conversionMap.convertRanges(seeds, []SourceRange{})
+	seedRanges := conversionMap.ConvertRanges(seeds, []SourceRange{})
 	return getFinalLocations(seedRanges, conversionMaps[1:], maps)
 }
 
-// getSeedRanges() is a takes a []string of start/end range pairs and converts
+// getSeedRanges() is a takes a []int of start/range pairs and converts
 // them into []SourceRange for Part 2.
-func getSeedRanges(rawRangeStr []string, seeds []SourceRange) []SourceRange {
-	if len(rawRangeStr) == 0 {
+func getSeedRanges(rawRanges []int, seeds []SourceRange) []SourceRange {
+	if len(rawRanges) < 2 {
 		return seeds
 	}
 
-	startStr, rangeStr := rawRangeStr[0], rawRangeStr[1]
-	start, _ := strconv.Atoi(startStr)
-	range_, _ := strconv.Atoi(rangeStr)
+	start, range_ := rawRanges[0], rawRanges[1]
 	end := start + range_ - 1
 
-	seeds = append(seeds, SourceRange{start: start, end: end, range_: range_})
-	return getSeedRanges(rawRangeStr[2:], seeds)
+	seeds = append(seeds, SourceRange{Start: start, End: end, Range: range_})
+	return getSeedRanges(rawRanges[2:], seeds)
 }
 
 // NewMapping() creates a new Mapping object.
@@ -252,13 +282,19 @@ func NewMapping(name string, numbers []int) Mapping {
 
 // getSeeds() gets seeds for Part 1.
 func getSeeds(lines []string) ([]int, error) {
-	matches := regexSeeds.FindAllString(lines[0], -1)
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "seeds:") {
+		return []int{}, &input.ParseError{Day: 5, Column: 1, Msg: `expected "seeds:"`}
+	}
+	matches := regexSeeds.FindAllStringIndex(lines[0], -1)
 	if matches == nil {
-		return []int{}, errors.New("can't find seed")
+		return []int{}, &input.ParseError{Day: 5, Msg: "can't find seed"}
 	}
 	seeds := []int{}
-	for _, numStr := range matches {
-		seed, _ := strconv.Atoi(numStr)
+	for _, match := range matches {
+		seed, err := input.Atoi(5, lines[0][match[0]:match[1]], match[0]+1)
+		if err != nil {
+			return []int{}, err
+		}
 		seeds = append(seeds, seed)
 	}
 
@@ -268,8 +304,8 @@ func getSeeds(lines []string) ([]int, error) {
 // sourceInMap() returns true if a source is in the map, and also returns
 // its destination for Part 1.
 func (m *Mapping) sourceInMap(source int) (bool, int) {
-	if m.sourceStart <= source && source <= m.sourceStart+m.range_ {
-		return true, m.destinationStart + (source - m.sourceStart)
+	if m.SourceStart <= source && source < m.SourceStart+m.Range {
+		return true, m.DestinationStart + (source - m.SourceStart)
 	}
 
 	return false, source
@@ -279,7 +315,7 @@ func (m *Mapping) sourceInMap(source int) (bool, int) {
 func (cMap ConversionMap) getDestination(mapNamp string, source int) int {
 	sourceCopy := source
 	for _, mapping := range cMap {
-		if mapping.name == mapNamp {
+		if mapping.Name == mapNamp {
 			inMap, num := mapping.sourceInMap(sourceCopy)
 			if inMap {
 				return num
@@ -1,71 +1,47 @@
-package main
+package day5
 
 import (
 	"bytes"
+	"context"
 	"reflect"
+	"strings"
 	"testing"
-)
-
-const testInput = `seeds: 79 14 55 13
-
-seed-to-soil map:
-50 98 2
-52 50 48
-
-soil-to-fertilizer map:
-0 15 37
-37 52 2
-39 0 15
-
-fertilizer-to-water map:
-49 53 8
-0 11 42
-42 0 7
-57 7 4
-
-water-to-light map:
-88 18 7
-18 25 70
-
-light-to-temperature map:
-45 77 23
-81 45 19
-68 64 13
 
-temperature-to-humidity map:
-0 69 1
-1 0 69
-
-humidity-to-location map:
-60 56 37
-56 93 4`
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
 var expectedFirst = Mapping{
-	name:             "seed-to-soil",
-	destinationStart: 50,
-	sourceStart:      98,
-	range_:           2,
+	Name:             "seed-to-soil",
+	DestinationStart: 50,
+	SourceStart:      98,
+	Range:            2,
 }
 
 var expectedSecond = Mapping{
-	name:             "seed-to-soil",
-	destinationStart: 52,
-	sourceStart:      50,
-	range_:           48,
+	Name:             "seed-to-soil",
+	DestinationStart: 52,
+	SourceStart:      50,
+	Range:            48,
 	// },
 }
 
 var expectedLast = Mapping{
-	name:             "humidity-to-location",
-	destinationStart: 56,
-	sourceStart:      93,
-	range_:           4,
+	Name:             "humidity-to-location",
+	DestinationStart: 56,
+	SourceStart:      93,
+	Range:            4,
 }
 
 func TestGetSeeds(t *testing.T) {
 	expected := []int{79, 14, 55, 13}
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
 	got, err := getSeeds(lines)
 	if err != nil {
 		t.Fatal(err)
@@ -75,14 +51,57 @@ func TestGetSeeds(t *testing.T) {
 	}
 }
 
+func TestParseAlmanac(t *testing.T) {
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	got, err := ParseAlmanac(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSeeds := []int{79, 14, 55, 13}
+	if !reflect.DeepEqual(got.Seeds, expectedSeeds) {
+		t.Fatalf("Expected %v, but got %v", expectedSeeds, got.Seeds)
+	}
+
+	if len(got.Maps) != 18 {
+		t.Fatalf("Expected %d Maps, but got %d", 18, len(got.Maps))
+	}
+
+	expected := 35
+	if lowest := got.LowestLocation(); lowest != expected {
+		t.Fatalf("Expected %d, but got %d", expected, lowest)
+	}
+}
+
+func TestParseAlmanacErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"", "day 5: line 1: can't find seed"},
+		{"79 14 55 13\n", `day 5: line 1, column 1: expected "seeds:"`},
+		{"seeds: 79 99999999999999999999\n", `day 5: line 1, column 11: invalid number "99999999999999999999": value out of range`},
+		{"seeds: 79\n\n50 98 2\n", "day 5: line 3, column 1: expected a map name before its mappings"},
+		{"seeds: 79\n\nseed-to-soil map:\n50 98 2\n52 50\n", `day 5: line 5, column 1: expected "<name> map:" or three numbers`},
+		{"seeds: 79\n\nseed-to-soil map:\n50 98 99999999999999999999\n", `day 5: line 4, column 7: invalid number "99999999999999999999": value out of range`},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseAlmanac(bytes.NewBufferString(tc.input))
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
 func TestNewMap(t *testing.T) {
 	inputNumbers := []int{50, 98, 2}
 	inputName := "seed-to-soil"
 	expected := Mapping{
-		name:             "seed-to-soil",
-		destinationStart: 50,
-		sourceStart:      98,
-		range_:           2,
+		Name:             "seed-to-soil",
+		DestinationStart: 50,
+		SourceStart:      98,
+		Range:            2,
 	}
 
 	got := NewMapping(inputName, inputNumbers)
@@ -92,10 +111,17 @@ func TestNewMap(t *testing.T) {
 }
 
 func TestGetMaps(t *testing.T) {
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	got := getMaps(lines, ConversionMap{}, "")
+	// Skip the seeds and the blank line after them.
+	got, err := getMaps(lines[2:], 3, ConversionMap{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	expectedLength := 18
 	gotLength := len(got)
@@ -123,6 +149,8 @@ func TestDestinationInMap(t *testing.T) {
 		{"not in map 14", 14, 14, false},
 		{"in map 55", 55, 57, true},
 		{"not in map 13", 13, 13, false},
+		{"last in map 97", 97, 99, true},
+		{"just past map 98", 98, 98, false},
 	}
 
 	mapping := expectedSecond
@@ -163,9 +191,15 @@ func TestGetDestination(t *testing.T) {
 }
 
 func TestGetSeedLocation(t *testing.T) {
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
-	maps := getMaps(lines, ConversionMap{}, "")
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maps, err := getMaps(lines[2:], 3, ConversionMap{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
 	// allMaps := Maps{maps}
 
 	testCases := []struct {
@@ -187,14 +221,14 @@ func TestGetSeedLocation(t *testing.T) {
 }
 
 func TestGetSeedRanges(t *testing.T) {
-	rawRangeStr := []string{"48", "53", "200", "2", "10", "2"}
+	rawRanges := []int{48, 53, 200, 2, 10, 2}
 	expected := []SourceRange{
 		{48, 100, 53},
 		{200, 201, 2},
 		{10, 11, 2},
 	}
 
-	got := getSeedRanges(rawRangeStr, []SourceRange{})
+	got := getSeedRanges(rawRanges, []SourceRange{})
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("Expected %v, but got %v", expected, got)
 	}
@@ -244,8 +278,8 @@ func TestGetSeedDestinations(t *testing.T) {
 
 func TestLowestSeedInRange(t *testing.T) {
 	expected := 46
-	buffer := bytes.NewBufferString(testInput)
-	got, err := run(buffer, Part2)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	got, err := run(buffer, part2)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -253,3 +287,33 @@ func TestLowestSeedInRange(t *testing.T) {
 		t.Fatalf("Expected %d, but got %d", expected, got)
 	}
 }
+
+func TestExamples(t *testing.T) {
+	golden.Run(t, 5)
+}
+
+func TestOracle(t *testing.T) {
+	differential.Run(t, 5)
+}
+
+func TestOracleEmpty(t *testing.T) {
+	for _, part := range solver.Parts {
+		_, err := solver.Puzzle{Day: 5, Solver: Oracle{}}.Solve(context.Background(), part, strings.NewReader(""))
+		expected := "day 5: line 1: can't find seed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected %q for part %d, but got %v", expected, part, err)
+		}
+	}
+}
+
+func FuzzParseAlmanac(f *testing.F) {
+	f.Add(golden.Input(f, "example1.txt"))
+	f.Fuzz(func(t *testing.T, almanac string) {
+		parsed, err := ParseAlmanac(strings.NewReader(almanac))
+		if err != nil {
+			return
+		}
+		parsed.LowestLocation()
+		parsed.LowestRangeLocation()
+	})
+}
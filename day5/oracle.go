@@ -0,0 +1,79 @@
+package day5
+
+import (
+	"context"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+)
+
+// Oracle solves day 5 by walking every seed, one at a time, through the
+// maps. It is for checking Solver against, and only solves valid inputs with
+// small seed ranges.
+type Oracle struct{}
+
+// Part1 returns the lowest location of the listed seeds, mapping each seed
+// without the parser or lookups Solver uses.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", &input.ParseError{Day: 5, Line: 1, Msg: "can't find seed"}
+	}
+
+	lowest := math.MaxInt64
+	for _, field := range strings.Fields(strings.TrimPrefix(lines[0], "seeds:")) {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return "", err
+		}
+
+		// Each "map:" line starts the next map. The first mapping whose
+		// source holds the value moves it, and the rest of the map is
+		// skipped.
+		moved := false
+		for _, line := range lines[1:] {
+			numbers := strings.Fields(line)
+			switch {
+			case strings.HasSuffix(line, "map:"):
+				moved = false
+			case len(numbers) == 3 && !moved:
+				destination, _ := strconv.Atoi(numbers[0])
+				source, _ := strconv.Atoi(numbers[1])
+				length, _ := strconv.Atoi(numbers[2])
+				if source <= value && value < source+length {
+					value = destination + value - source
+					moved = true
+				}
+			}
+		}
+		lowest = min(lowest, value)
+	}
+	return strconv.Itoa(lowest), nil
+}
+
+// Part2 returns the lowest location of the listed seed ranges, walking every
+// seed in every range through getSeedLocation.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	almanac, err := ParseAlmanac(r)
+	if err != nil {
+		return "", err
+	}
+
+	lowest := math.MaxInt64
+	for i := 0; i+1 < len(almanac.Seeds); i += 2 {
+		start, length := almanac.Seeds[i], almanac.Seeds[i+1]
+		for seed := start; seed < start+length; seed++ {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			lowest = min(lowest, almanac.SeedLocation(seed))
+		}
+	}
+	return strconv.Itoa(lowest), nil
+}
@@ -1,90 +1,79 @@
-package main
+// Package day6 multiplies together the number of ways to win each boat race.
+package day6
 
 import (
-	"bufio"
-	"flag"
+	"context"
 	"fmt"
 	"io"
-	"os"
 	"regexp"
-	"strconv"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
 )
 
-type PuzzlePart int
+var numberRegex = regexp.MustCompile(`\d+`)
+
+type puzzlePart int
 
 const (
-	Part1 PuzzlePart = iota
-	Part2
+	part1 puzzlePart = iota
+	part2
 )
 
 // Race represents a race.
 type Race struct {
-	time           int
-	distanceRecord int
-	waysToWin      int
+	Time           int
+	DistanceRecord int
 }
 
 // Races represents a slice of Race.
 type Races []Race
 
-const filename = "day6_input.txt"
-
-func main() {
-	part := flag.Int("part", 1, "the integer value of the puzzle to run (1 or 2)")
-	flag.Parse()
-	if *part < 1 || *part > 2 {
-		fmt.Println("Expected the part value to be 1 or 2.")
-		os.Exit(1)
-	}
+func init() {
+	solver.Register(6, "Wait For It", Solver{})
+	solver.RegisterOracle(6, Oracle{})
+}
 
-	var puzzlePart PuzzlePart
-	switch *part {
-	case 1:
-		puzzlePart = Part1
-	case 2:
-		puzzlePart = Part2
-	}
+// Solver solves day 6.
+type Solver struct{}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
+// Part1 multiplies the ways to win each race.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part1))
+}
 
-	result := run(file, puzzlePart)
-	fmt.Println(result)
+// Part2 returns the ways to win the single, kerned race.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part2))
 }
 
 // NewRace() returns a Race.
 func NewRace(time int, distance int) Race {
-	return Race{time: time, distanceRecord: distance}
+	return Race{Time: time, DistanceRecord: distance}
 }
 
-// getLines() returns the file input as []string.
-func getLines(file io.Reader) []string {
-	scanner := bufio.NewScanner(file)
-	lines := []string{}
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// getRaces() takes a []string and returns all the Races.
+func getRaces(lines []string, part puzzlePart) (Races, error) {
+	if len(lines) < 2 {
+		return nil, &input.ParseError{Day: 6, Line: len(lines) + 1, Msg: "expected a Time line and a Distance line"}
 	}
 
-	return lines
-}
+	convert := lineToIntsPart1
+	if part == part2 {
+		convert = lineToIntsPart2
+	}
 
-// getRaces() takes a []string and returns all the Races.
-func getRaces(lines []string, puzzlePart PuzzlePart) Races {
-	regex := regexp.MustCompile(`\d+`)
-	timeMatchStr := regex.FindAllString(lines[0], -1)
-	distanceMatchStr := regex.FindAllString(lines[1], -1)
-
-	timeMatch := []int{}
-	distanceMatch := []int{}
-	switch puzzlePart {
-	case Part1:
-		timeMatch = strSliceToIntPart1(timeMatchStr)
-		distanceMatch = strSliceToIntPart1(distanceMatchStr)
-	case Part2:
-		timeMatch = strSliceToIntPart2(timeMatchStr)
-		distanceMatch = strSliceToIntPart2(distanceMatchStr)
+	timeMatch, err := convert(lines[0])
+	if err != nil {
+		return nil, input.AtLine(err, 1)
+	}
+	distanceMatch, err := convert(lines[1])
+	if err != nil {
+		return nil, input.AtLine(err, 2)
+	}
+	if len(timeMatch) != len(distanceMatch) {
+		msg := fmt.Sprintf("expected %d distances to match the times, but got %d", len(timeMatch), len(distanceMatch))
+		return nil, &input.ParseError{Day: 6, Line: 2, Msg: msg}
 	}
 
 	races := Races{}
@@ -92,57 +81,82 @@ func getRaces(lines []string, puzzlePart PuzzlePart) Races {
 		races = append(races, NewRace(timeMatch[i], distanceMatch[i]))
 	}
 
-	return races
+	return races, nil
 }
 
-func run(file io.Reader, puzzlePart PuzzlePart) int {
-	lines := getLines(file)
+func run(ctx context.Context, file io.Reader, part puzzlePart) (int, error) {
+	lines, err := input.Lines(file)
+	if err != nil {
+		return 0, err
+	}
 
-	var races Races
-	switch puzzlePart {
-	case Part1:
-		races = getRaces(lines, Part1)
-	case Part2:
-		races = getRaces(lines, Part2)
+	races, err := getRaces(lines, part)
+	if err != nil {
+		return 0, err
 	}
 
 	result := 1
 	for _, race := range races {
-		result *= race.getWaysToWin()
+		ways, err := race.waysToWin(ctx)
+		if err != nil {
+			return 0, err
+		}
+		result *= ways
 	}
+	return result, nil
+}
+
+// WaysToWin() returns the number of ways to win in Part1.
+func (r Race) WaysToWin() int {
+	result, _ := r.waysToWin(context.Background())
 	return result
 }
 
-// getWaysToWin() returns the number of ways to win in Part1.
-func (r Race) getWaysToWin() int {
+// waysToWin() counts the ways to win, giving up once ctx is done. The kerned
+// race of part 2 takes tens of millions of steps.
+func (r Race) waysToWin(ctx context.Context) (int, error) {
 	result := 0
-	for i := 0; i < r.time; i++ {
-		if i*(r.time-i) > r.distanceRecord {
+	for i := 0; i < r.Time; i++ {
+		if i%(1<<20) == 0 && ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if i*(r.Time-i) > r.DistanceRecord {
 			result++
 		}
 	}
 
-	return result
+	return result, nil
 }
 
 // Helper utilities
 
-// strSliceToIntPart1() converts a []string to []int.
-func strSliceToIntPart1(slice []string) []int {
-	result := []int{}
-	for _, numStr := range slice {
-		num, _ := strconv.Atoi(numStr)
-		result = append(result, num)
+// lineToIntsPart1() converts the numbers in a line to []int.
+func lineToIntsPart1(line string) ([]int, error) {
+	result, err := input.Ints(6, line)
+	if err != nil {
+		return nil, err
 	}
-	return result
+	if len(result) == 0 {
+		return nil, &input.ParseError{Day: 6, Msg: "expected at least one number"}
+	}
+	return result, nil
 }
 
-// strSliceToIntPart2() converts a []string to []int.
-func strSliceToIntPart2(slice []string) []int {
+// lineToIntsPart2() joins the numbers in a line, ignoring the spaces between
+// them, and converts the result to []int.
+func lineToIntsPart2(line string) ([]int, error) {
+	matches := numberRegex.FindAllStringIndex(line, -1)
+	if matches == nil {
+		return nil, &input.ParseError{Day: 6, Msg: "expected at least one number"}
+	}
+
 	resultStr := ""
-	for _, numStr := range slice {
-		resultStr += numStr
+	for _, match := range matches {
+		resultStr += line[match[0]:match[1]]
+	}
+	num, err := input.Atoi(6, resultStr, matches[0][0]+1)
+	if err != nil {
+		return nil, err
 	}
-	num, _ := strconv.Atoi(resultStr)
-	return []int{num}
+	return []int{num}, nil
 }
@@ -1,13 +1,16 @@
-package main
+package day6
 
 import (
 	"bytes"
+	"context"
 	"reflect"
+	"strings"
 	"testing"
-)
 
-var testInput = `Time:      7  15   30
-Distance:  9  40  200`
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+)
 
 var testInput2 = `Time:      71530
 Distance:  940200`
@@ -21,16 +24,43 @@ var (
 var races = Races{race1, race2, race3}
 
 func TestGetRaces(t *testing.T) {
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := races
 
-	got := getRaces(lines, Part1)
+	got, err := getRaces(lines, part1)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("Expected %v, but got %v", expected, got)
 	}
 }
 
+func TestGetRacesErrors(t *testing.T) {
+	testCases := []struct {
+		lines    []string
+		part     puzzlePart
+		expected string
+	}{
+		{[]string{"Time:      7  15   30"}, part1, "day 6: line 2: expected a Time line and a Distance line"},
+		{[]string{"Time:", "Distance:  9  40  200"}, part1, "day 6: line 1: expected at least one number"},
+		{[]string{"Time:      7  15   30", "Distance:  9  40"}, part1, "day 6: line 2: expected 3 distances to match the times, but got 2"},
+		{[]string{"Time:      7  99999999999999999999", "Distance:  9  40"}, part1, `day 6: line 1, column 15: invalid number "99999999999999999999": value out of range`},
+		{[]string{"Time:      7  15   30", "Distance:  9999999999  9999999999"}, part2, `day 6: line 2, column 12: invalid number "99999999999999999999": value out of range`},
+	}
+
+	for _, tc := range testCases {
+		_, err := getRaces(tc.lines, tc.part)
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
 func TestGetWaysToWin(t *testing.T) {
 	testCases := []struct {
 		race     Race
@@ -42,27 +72,40 @@ func TestGetWaysToWin(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		got := tc.race.getWaysToWin()
+		got := tc.race.WaysToWin()
 		if got != tc.expected {
 			t.Fatalf("Expected %v, but got %v", tc.expected, got)
 		}
 	}
 }
 
-func TestRunPart1(t *testing.T) {
-	expected := 288
-	buffer := bytes.NewBufferString(testInput)
-	got := run(buffer, Part1)
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buffer := bytes.NewBufferString(testInput2)
+	if _, err := run(ctx, buffer, part2); err != context.Canceled {
+		t.Fatalf("Expected %v, but got %v", context.Canceled, err)
 	}
 }
 
-func TestRunPart2(t *testing.T) {
-	expected := 71503
-	buffer := bytes.NewBufferString(testInput)
-	got := run(buffer, Part2)
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
-	}
+func TestExamples(t *testing.T) {
+	golden.Run(t, 6)
+}
+
+func TestOracle(t *testing.T) {
+	differential.Run(t, 6)
+}
+
+func FuzzGetRaces(f *testing.F) {
+	f.Add(golden.Input(f, "example1.txt"))
+	f.Add(testInput2)
+	f.Fuzz(func(t *testing.T, sheet string) {
+		lines, err := input.Lines(strings.NewReader(sheet))
+		if err != nil {
+			return
+		}
+		getRaces(lines, part1)
+		getRaces(lines, part2)
+	})
 }
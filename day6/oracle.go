@@ -0,0 +1,68 @@
+package day6
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Oracle solves day 6 by trying every time the button could be held. It is
+// for checking Solver against, and only solves valid inputs.
+type Oracle struct{}
+
+// Part1 multiplies the ways to win each race.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, part1))
+}
+
+// Part2 returns the ways to win the single, kerned race.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, part2))
+}
+
+func oracleRun(r io.Reader, part puzzlePart) (int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return 0, err
+	}
+
+	numbers := func(line string) ([]int, error) {
+		_, fields, _ := strings.Cut(line, ":")
+		if part == part2 {
+			fields = strings.ReplaceAll(fields, " ", "")
+		}
+		var result []int
+		for _, field := range strings.Fields(fields) {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, n)
+		}
+		return result, nil
+	}
+	times, err := numbers(lines[0])
+	if err != nil {
+		return 0, err
+	}
+	records, err := numbers(lines[1])
+	if err != nil {
+		return 0, err
+	}
+
+	result := 1
+	for i, time := range times {
+		ways := 0
+		for hold := 0; hold <= time; hold++ {
+			if hold*(time-hold) > records[i] {
+				ways++
+			}
+		}
+		result *= ways
+	}
+	return result, nil
+}
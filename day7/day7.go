@@ -1,26 +1,32 @@
-package main
+// Package day7 ranks Camel Cards hands and returns their total winnings.
+package day7
 
 import (
-	"bufio"
-	"flag"
+	"context"
 	"fmt"
 	"io"
-	"os"
 	"regexp"
 	"sort"
 	"strconv"
-)
 
-const filename = "day7_input.txt"
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
 
+// Hand is a hand of cards and its bid. wildCards holds the strongest hand the
+// jokers can make, once set by MakeWildCards().
 type Hand struct {
-	cards     []int
-	bid       int
+	Cards     []int
+	Bid       int
 	wildCards []int
 }
 
+// Hands represents a slice of Hand.
 type Hands []Hand
 
+// The number of cards in a hand.
+const handSize = 5
+
 // Part 1 cards
 const (
 	T1 = iota + 10
@@ -40,6 +46,10 @@ const (
 	A2
 )
 
+// UnknownHand is the hand type of a hand with no cards or more than five,
+// which ranks below every other.
+const UnknownHand = -1
+
 // Represent the hand types in rank order, with the first being the lowest.
 const (
 	HighCard = iota
@@ -51,31 +61,31 @@ const (
 	FiveOfAKind
 )
 
-type PuzzlePart int
+type puzzlePart int
 
 const (
-	Part1 PuzzlePart = iota
-	Part2
+	part1 puzzlePart = iota
+	part2
 )
 
-// newHand() creates a new Hand of cards.
-func newHand(cards []int, bid int) Hand {
+// NewHand() creates a new Hand of cards.
+func NewHand(cards []int, bid int) Hand {
 	return Hand{cards, bid, []int{}}
 }
 
-// h.makeWildHands() will set .wildCards for each hand, if applicable.
-func (h *Hands) makeWildHands() {
+// h.MakeWildHands() will set .wildCards for each hand, if applicable.
+func (h *Hands) MakeWildHands() {
 	for i := range *h {
-		(*h)[i].makeWildCards()
+		(*h)[i].MakeWildCards()
 	}
 }
 
-// h.makeWildCards() uses any wild cards ("J") to create a hand of the highest
+// h.MakeWildCards() uses any wild cards ("J") to create a hand of the highest
 // possible value.
-func (h *Hand) makeWildCards() {
+func (h *Hand) MakeWildCards() {
 	// Get the indexes of Js to use for generation and replacement.
 	jIndexes := []int{}
-	for i, v := range h.cards {
+	for i, v := range h.Cards {
 		if v == J2 {
 			jIndexes = append(jIndexes, i)
 		}
@@ -91,70 +101,81 @@ func (h *Hand) makeWildCards() {
 
 	// Slot the generated combinations into the proper index, and check if
 	// it has generated a new highestHand.
-	highestHand := newHand(h.cards, 0)
+	highestHand := NewHand(h.Cards, 0)
 	for _, combo := range jokerCombos {
-		tmpCards := make([]int, len(highestHand.cards))
-		copy(tmpCards, highestHand.cards)
+		tmpCards := make([]int, len(highestHand.Cards))
+		copy(tmpCards, highestHand.Cards)
 		for jIdx, jokerIdxInHand := range jIndexes {
 			tmpCards[jokerIdxInHand] = combo[jIdx]
 		}
-		tmpHand := newHand(tmpCards, 0)
-		if tmpHand.classifyHand() > highestHand.classifyHand() {
+		tmpHand := NewHand(tmpCards, 0)
+		if tmpHand.ClassifyHand() > highestHand.ClassifyHand() {
 			highestHand = tmpHand
 		}
 	}
 
-	h.wildCards = highestHand.cards
+	h.wildCards = highestHand.Cards
 }
 
-func main() {
-	part := flag.Int("part", 1, "specify a puzzle part (1 or 2)")
-	flag.Parse()
-	if *part < 1 || *part > 2 {
-		fmt.Println("Expected 'part' argument to be 1 or 2")
-	}
+func init() {
+	solver.Register(7, "Camel Cards", Solver{})
+	solver.RegisterOracle(7, Oracle{})
+}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
+// Solver solves day 7.
+type Solver struct{}
 
-	result := 0
-	switch *part {
-	case 1:
-		result = run(file, Part1)
-	case 2:
-		result = run(file, Part2)
-	}
+// Part1 returns the total winnings.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part1))
+}
 
-	fmt.Println("result:", result)
+// Part2 returns the total winnings with J as a joker.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part2))
 }
 
 // run() is the entrypoint to the program.
-func run(file io.Reader, part PuzzlePart) int {
-	lines := getLines(file)
-	hands := getHands(lines, part)
+func run(ctx context.Context, file io.Reader, part puzzlePart) (int, error) {
+	lines, err := input.Lines(file)
+	if err != nil {
+		return 0, err
+	}
+	hands, err := getHands(lines, part)
+	if err != nil {
+		return 0, err
+	}
 
 	switch part {
-	case Part1:
-		return hands.getTotalWinnings()
-	case Part2:
-		hands.makeWildHands()
-		return hands.getTotalWinnings()
+	case part1:
+		return hands.TotalWinnings(), nil
+	case part2:
+		// Like hands.MakeWildHands(), but each hand can enumerate up to 13^5
+		// joker combinations, so check for cancellation between them.
+		for i := range hands {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			hands[i].MakeWildCards()
+		}
+		return hands.TotalWinnings(), nil
 	}
 
-	return 1
+	return 1, nil
 }
 
 // classifyCard() turns an single card string into its int representation.
-// E.g. "A" -> 15.
-func classifyCard(cardStr string, part PuzzlePart) int {
+// E.g. "A" -> 15. It returns 0 for a string that isn't a card.
+func classifyCard(cardStr string, part puzzlePart) int {
 	// number-strings convert cleanly, and "A", "K", etc., get an error.
 	result, err := strconv.Atoi(cardStr)
+	if err == nil && (result < 2 || result > 9) {
+		return 0
+	}
 
 	// Card values change between parts 1 and 2.
 	switch part {
-	case Part1:
+	case part1:
 		if err != nil {
 			switch cardStr {
 			case "A":
@@ -169,7 +190,7 @@ func classifyCard(cardStr string, part PuzzlePart) int {
 				result = T1
 			}
 		}
-	case Part2:
+	case part2:
 		if err != nil {
 			switch cardStr {
 			case "A":
@@ -189,9 +210,9 @@ func classifyCard(cardStr string, part PuzzlePart) int {
 	return result
 }
 
-// classifyHand() returns the value of a hand type (e.g. 0 for High Card and 6
-// for Five of a Kind).
-func (h Hand) classifyHand() int {
+// ClassifyHand() returns the value of a hand type (e.g. 0 for High Card and 6
+// for Five of a Kind), or UnknownHand.
+func (h Hand) ClassifyHand() int {
 	// Calculate how many times each card is seen - for determining hands.
 	// Use h.wildCards if it's set (i.e. do Part2).
 	cardMap := make(map[int]int)
@@ -200,7 +221,7 @@ func (h Hand) classifyHand() int {
 			cardMap[card]++
 		}
 	} else {
-		for _, card := range h.cards {
+		for _, card := range h.Cards {
 			cardMap[card]++
 		}
 	}
@@ -241,79 +262,79 @@ func (h Hand) classifyHand() int {
 	case 5:
 		return HighCard
 	default:
-		fmt.Println("Unrecognized hand")
-		os.Exit(1)
-	}
-
-	if len(cardMap) == 3 {
-		for _, v := range cardMap {
-			if v == 3 {
-				return ThreeOfAKind
-			} else {
-				return TwoPair
-			}
-		}
+		return UnknownHand
 	}
-
-	return 0
 }
 
-// isStronger() returns true if otherHand is stronger and false otherwise.
-func (h Hand) isStrongerThan(otherHand Hand) bool {
+// IsStrongerThan() returns true if h is stronger than otherHand and false otherwise.
+func (h Hand) IsStrongerThan(otherHand Hand) bool {
 	// When hand-type is identical, the hand with the highest first card is stronger.
-	if otherHand.classifyHand() == h.classifyHand() {
-		for i, card := range h.cards {
-			if card == otherHand.cards[i] {
+	if otherHand.ClassifyHand() == h.ClassifyHand() {
+		for i, card := range h.Cards {
+			if card == otherHand.Cards[i] {
 				continue
 			}
-			return card > otherHand.cards[i]
+			return card > otherHand.Cards[i]
 		}
 	}
 
 	// The cards weren't identical, so simply compare hand types.
-	return h.classifyHand() > otherHand.classifyHand()
+	return h.ClassifyHand() > otherHand.ClassifyHand()
 }
 
 // getHands() converts lines of unparsed text into []Hand.
-func getHands(lines []string, part PuzzlePart) Hands {
+func getHands(lines []string, part puzzlePart) (Hands, error) {
 	regex := regexp.MustCompile(`(\d+|\w+) (\d+)`)
 	result := []Hand{}
 
-	for _, line := range lines {
-		match := regex.FindStringSubmatch(line)
-		handStr := match[1]
-		bidStr := match[2]
-		bid, err := strconv.Atoi(bidStr)
+	for i, line := range lines {
+		lineNo := i + 1
+		match := regex.FindStringSubmatchIndex(line)
+		if match == nil {
+			return nil, &input.ParseError{Day: 7, Line: lineNo, Column: 1, Msg: `expected "<hand> <bid>"`}
+		}
+		handStart, handStr := match[2], line[match[2]:match[3]]
+		bid, err := input.Atoi(7, line[match[4]:match[5]], match[4]+1)
 		if err != nil {
-			panic(err)
+			return nil, input.AtLine(err, lineNo)
+		}
+
+		if len(handStr) != handSize {
+			msg := fmt.Sprintf("expected %d cards, but got %d", handSize, len(handStr))
+			return nil, &input.ParseError{Day: 7, Line: lineNo, Column: handStart + 1, Msg: msg}
 		}
 
 		cards := []int{}
-		for _, cardStr := range handStr {
-			cards = append(cards, classifyCard(string(cardStr), part))
+		for cardIdx, cardStr := range handStr {
+			card := classifyCard(string(cardStr), part)
+			if card == 0 {
+				msg := fmt.Sprintf("unknown card %q", cardStr)
+				return nil, &input.ParseError{Day: 7, Line: lineNo, Column: handStart + cardIdx + 1, Msg: msg}
+			}
+			cards = append(cards, card)
 		}
 
-		result = append(result, newHand(cards, bid))
+		result = append(result, NewHand(cards, bid))
 	}
 
-	return result
+	return result, nil
 }
 
 // Sorting Hands by reverse rank.
 func (h Hands) Len() int           { return len(h) }
-func (h Hands) Less(i, j int) bool { return h[i].isStrongerThan(h[j]) }
+func (h Hands) Less(i, j int) bool { return h[i].IsStrongerThan(h[j]) }
 func (h Hands) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 func (h Hands) sort() {
 	sort.Sort(sort.Reverse(h))
 }
 
-// getTotalWinnings() returns the total winnings for a set of hands.
-func (h Hands) getTotalWinnings() int {
+// TotalWinnings() returns the total winnings for a set of hands.
+func (h Hands) TotalWinnings() int {
 	// First sort the hands before totaling.
 	h.sort()
 	total := 0
 	for i, hand := range h {
-		total += (i + 1) * hand.bid
+		total += (i + 1) * hand.Bid
 	}
 
 	return total
@@ -321,15 +342,6 @@ func (h Hands) getTotalWinnings() int {
 
 // Helper functions
 
-func getLines(file io.Reader) []string {
-	lines := []string{}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines
-}
-
 // getCombinations() generates a [][]int with all possible combination of cards
 // in an []int of len(slice).
 func getCombinations(slice []int) [][]int {
@@ -1,42 +1,48 @@
-package main
+package day7
 
 import (
 	"bytes"
+	"context"
 	"reflect"
+	"strings"
 	"testing"
-)
 
-var testInput = `32T3K 765
-T55J5 684
-KK677 28
-KTJJT 220
-QQQJA 483`
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/internal/differential"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+)
 
 var (
-	hand1   = newHand([]int{3, 2, 10, 3, 13}, 765)    // One pair       = 4
-	hand2   = newHand([]int{10, 5, 5, 11, 5}, 684)    // Three of a kind
-	hand3   = newHand([]int{13, 13, 6, 7, 7}, 28)     // Two pair
-	hand4   = newHand([]int{13, 10, 11, 11, 10}, 220) // Two pair       = 3
-	hand5   = newHand([]int{12, 12, 12, 11, 14}, 483) // Three of a kind = 3
-	hand6   = newHand([]int{1, 1, 1, 2, 2}, 200)      // Full house     = 2
-	hand7   = newHand([]int{2, 2, 2, 2, 3}, 400)      // Four of a kind = 2
-	hand8   = newHand([]int{3, 3, 3, 3, 3}, 500)      // Five of a kind = 1
-	hand9   = newHand([]int{1, 2, 3, 4, 5}, 1)        // High card      = 5
-	hand1p2 = newHand([]int{3, 2, 10, 3, 12}, 765)    // One pair       = 4
-	hand2p2 = newHand([]int{10, 5, 5, 1, 5}, 684)     // Four of a kind
-	hand3p2 = newHand([]int{12, 12, 6, 7, 7}, 28)     // Two pair
-	hand4p2 = newHand([]int{12, 10, 1, 1, 10}, 220)   // Four of a kind
-	hand5p2 = newHand([]int{11, 11, 11, 1, 13}, 483)  // Four of a kind
-	hand6p2 = newHand([]int{1, 1, 1, 2, 2}, 400)      // Five of a kind
-	hand7p2 = newHand([]int{2, 2, 2, 2, 3}, 400)      // Five of a kind = 2
-	hand8p2 = newHand([]int{1, 1, 1, 1, 2}, 500)      // Five of a kind = 1
+	hand1   = NewHand([]int{3, 2, 10, 3, 13}, 765)    // One pair       = 4
+	hand2   = NewHand([]int{10, 5, 5, 11, 5}, 684)    // Three of a kind
+	hand3   = NewHand([]int{13, 13, 6, 7, 7}, 28)     // Two pair
+	hand4   = NewHand([]int{13, 10, 11, 11, 10}, 220) // Two pair       = 3
+	hand5   = NewHand([]int{12, 12, 12, 11, 14}, 483) // Three of a kind = 3
+	hand6   = NewHand([]int{1, 1, 1, 2, 2}, 200)      // Full house     = 2
+	hand7   = NewHand([]int{2, 2, 2, 2, 3}, 400)      // Four of a kind = 2
+	hand8   = NewHand([]int{3, 3, 3, 3, 3}, 500)      // Five of a kind = 1
+	hand9   = NewHand([]int{1, 2, 3, 4, 5}, 1)        // High card      = 5
+	hand1p2 = NewHand([]int{3, 2, 10, 3, 12}, 765)    // One pair       = 4
+	hand2p2 = NewHand([]int{10, 5, 5, 1, 5}, 684)     // Four of a kind
+	hand3p2 = NewHand([]int{12, 12, 6, 7, 7}, 28)     // Two pair
+	hand4p2 = NewHand([]int{12, 10, 1, 1, 10}, 220)   // Four of a kind
+	hand5p2 = NewHand([]int{11, 11, 11, 1, 13}, 483)  // Four of a kind
+	hand6p2 = NewHand([]int{1, 1, 1, 2, 2}, 400)      // Five of a kind
+	hand7p2 = NewHand([]int{2, 2, 2, 2, 3}, 400)      // Five of a kind = 2
+	hand8p2 = NewHand([]int{1, 1, 1, 1, 2}, 500)      // Five of a kind = 1
 )
 
 func TestGetHandsPart1(t *testing.T) {
 	expected := Hands{hand1, hand2, hand3, hand4, hand5}
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
-	got := getHands(lines, Part1)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := getHands(lines, part1)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("Expected %v, but got %v", expected, got)
@@ -45,15 +51,41 @@ func TestGetHandsPart1(t *testing.T) {
 
 func TestGetHandsPart2(t *testing.T) {
 	expected := Hands{hand1p2, hand2p2, hand3p2, hand4p2, hand5p2}
-	buffer := bytes.NewBufferString(testInput)
-	lines := getLines(buffer)
-	got := getHands(lines, Part2)
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	lines, err := input.Lines(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := getHands(lines, part2)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("Expected %v, but got %v", expected, got)
 	}
 }
 
+func TestGetHandsErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"32T3K", `day 7: line 1, column 1: expected "<hand> <bid>"`},
+		{"32T3K 99999999999999999999", `day 7: line 1, column 7: invalid number "99999999999999999999": value out of range`},
+		{"32T3 765", "day 7: line 1, column 1: expected 5 cards, but got 4"},
+		{"32X3K 765", `day 7: line 1, column 3: unknown card 'X'`},
+		{"3213K 765", `day 7: line 1, column 3: unknown card '1'`},
+	}
+
+	for _, tc := range testCases {
+		_, err := getHands([]string{tc.input}, part1)
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
 func TestClassifyHandPart1(t *testing.T) {
 	testCases := []struct {
 		hand     Hand
@@ -71,13 +103,21 @@ func TestClassifyHandPart1(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		got := tc.hand.classifyHand()
+		got := tc.hand.ClassifyHand()
 		if got != tc.expected {
 			t.Fatalf("Expected %v, but got %v", tc.expected, got)
 		}
 	}
 }
 
+func TestClassifyUnknownHand(t *testing.T) {
+	for _, cards := range [][]int{{}, {2, 3, 4, 5, 6, 7}} {
+		if got := NewHand(cards, 0).ClassifyHand(); got != UnknownHand {
+			t.Fatalf("Expected %v, but got %v for %v", UnknownHand, got, cards)
+		}
+	}
+}
+
 func TestWildCards(t *testing.T) {
 	testCases := []struct {
 		hand     Hand
@@ -94,8 +134,8 @@ func TestWildCards(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		tc.hand.makeWildCards()
-		got := tc.hand.classifyHand()
+		tc.hand.MakeWildCards()
+		got := tc.hand.ClassifyHand()
 		if got != tc.expected {
 			t.Fatalf("Expected %v, but got %v for Hand: %v", tc.expected, got, tc.hand)
 		}
@@ -116,7 +156,7 @@ func TestIsStrongerThan(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		got := tc.firstHand.isStrongerThan(tc.secondHand)
+		got := tc.firstHand.IsStrongerThan(tc.secondHand)
 		if got != tc.expected {
 			t.Fatalf("Expected %v, but got %v: %v", tc.expected, got, tc.name)
 		}
@@ -136,26 +176,40 @@ func TestGetTotalWinnings(t *testing.T) {
 	expected := 6440
 	// sortedHands := Hands{hand1, hand4, hand3, hand2, hand5}
 	hands := Hands{hand1, hand2, hand3, hand4, hand5}
-	got := hands.getTotalWinnings()
+	got := hands.TotalWinnings()
 	if got != expected {
 		t.Fatalf("Expected %d, but got %d", expected, got)
 	}
 }
 
-func TestRunPart1(t *testing.T) {
-	buffer := bytes.NewBufferString(testInput)
-	expected := 6440
-	got := run(buffer, Part1)
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buffer := bytes.NewBufferString(golden.Input(t, "example1.txt"))
+	if _, err := run(ctx, buffer, part2); err != context.Canceled {
+		t.Fatalf("Expected %v, but got %v", context.Canceled, err)
 	}
 }
 
-func TestRunPart2(t *testing.T) {
-	buffer := bytes.NewBufferString(testInput)
-	expected := 5905
-	got := run(buffer, Part2)
-	if got != expected {
-		t.Fatalf("Expected %d, but got %d", expected, got)
-	}
+func TestExamples(t *testing.T) {
+	golden.Run(t, 7)
+}
+
+func TestOracle(t *testing.T) {
+	differential.Run(t, 7)
+}
+
+func FuzzGetHands(f *testing.F) {
+	f.Add(golden.Input(f, "example1.txt"))
+	f.Fuzz(func(t *testing.T, list string) {
+		lines, err := input.Lines(strings.NewReader(list))
+		if err != nil {
+			return
+		}
+		if hands, err := getHands(lines, part1); err == nil {
+			hands.TotalWinnings()
+		}
+		getHands(lines, part2)
+	})
 }
@@ -1,3 +0,0 @@
-module github.com/scottbarnes/advent-of-code-2023
-
-go 1.21
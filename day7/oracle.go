@@ -0,0 +1,119 @@
+package day7
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Oracle solves day 7 by counting each hand's cards to find its type and, in
+// part 2, trying every card in place of every joker. It is for checking
+// Solver against, and only solves valid inputs.
+type Oracle struct{}
+
+// Part1 returns the total winnings.
+func (Oracle) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, "23456789TJQKA", false))
+}
+
+// Part2 returns the total winnings with J as a joker.
+func (Oracle) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(oracleRun(r, "J23456789TQKA", true))
+}
+
+// oracleRun ranks the hands by type, then by their cards, in order from
+// weakest to strongest.
+func oracleRun(r io.Reader, order string, jokers bool) (int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return 0, err
+	}
+
+	type hand struct {
+		cards    string
+		bid      int
+		handType int
+	}
+	hands := make([]hand, len(lines))
+	for i, line := range lines {
+		cards, bid, _ := strings.Cut(line, " ")
+		hands[i].cards = cards
+		if hands[i].bid, err = strconv.Atoi(bid); err != nil {
+			return 0, err
+		}
+
+		substitutes := []string{cards}
+		if jokers {
+			for j := range cards {
+				if cards[j] != 'J' {
+					continue
+				}
+				var next []string
+				for _, s := range substitutes {
+					for _, card := range order[1:] {
+						next = append(next, s[:j]+string(card)+s[j+1:])
+					}
+				}
+				substitutes = next
+			}
+		}
+		for _, s := range substitutes {
+			hands[i].handType = max(hands[i].handType, oracleHandType(s))
+		}
+	}
+
+	sort.Slice(hands, func(i, j int) bool {
+		if hands[i].handType != hands[j].handType {
+			return hands[i].handType < hands[j].handType
+		}
+		for k := range hands[i].cards {
+			a := strings.IndexByte(order, hands[i].cards[k])
+			b := strings.IndexByte(order, hands[j].cards[k])
+			if a != b {
+				return a < b
+			}
+		}
+		return false
+	})
+
+	total := 0
+	for i, h := range hands {
+		total += (i + 1) * h.bid
+	}
+	return total, nil
+}
+
+// oracleHandType returns the type of a hand from how many of each card it
+// holds, from HighCard to FiveOfAKind.
+func oracleHandType(cards string) int {
+	counts := map[rune]int{}
+	for _, card := range cards {
+		counts[card]++
+	}
+	var sizes []int
+	for _, count := range counts {
+		sizes = append(sizes, count)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	switch {
+	case sizes[0] == 5:
+		return FiveOfAKind
+	case sizes[0] == 4:
+		return FourOfAKind
+	case sizes[0] == 3 && sizes[1] == 2:
+		return FullHouse
+	case sizes[0] == 3:
+		return ThreeOfAKind
+	case sizes[0] == 2 && sizes[1] == 2:
+		return TwoPair
+	case sizes[0] == 2:
+		return OnePair
+	}
+	return HighCard
+}
@@ -0,0 +1,100 @@
+package days
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/inputcrypt"
+	"github.com/scottbarnes/advent-of-code-2023/inputgen"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// BenchmarkSolvers runs every registered day and part on its real input and
+// on its first example. A real input may be committed encrypted. Inputs that
+// don't exist or can't be decrypted without a key, and parts that aren't
+// solved, are skipped.
+func BenchmarkSolvers(b *testing.B) {
+	for _, puzzle := range solver.Puzzles() {
+		inputs := []struct {
+			name string
+			path string
+		}{
+			{"real", fmt.Sprintf("../day%d/day%d_input.txt", puzzle.Day, puzzle.Day)},
+			{"example", fmt.Sprintf("../day%d/testdata/example1.txt", puzzle.Day)},
+		}
+
+		for _, part := range solver.Parts {
+			for _, in := range inputs {
+				puzzle, part, in := puzzle, part, in
+				b.Run(fmt.Sprintf("day%d/part%d/%s", puzzle.Day, part, in.name), func(b *testing.B) {
+					data, err := inputcrypt.ReadFile(in.path)
+					if errors.Is(err, os.ErrNotExist) || errors.Is(err, inputcrypt.ErrNoKey) {
+						b.Skip("no input: ", err)
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					_, err = puzzle.Solve(context.Background(), part, bytes.NewReader(data))
+					if errors.Is(err, solver.ErrUnsolved) {
+						b.Skip(err)
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						puzzle.Solve(context.Background(), part, bytes.NewReader(data))
+					}
+				})
+			}
+		}
+	}
+}
+
+// largeScale is how many real inputs' worth of lines BenchmarkLarge generates.
+const largeScale = 100
+
+// BenchmarkLarge runs every day and part that has a generator on an input 100
+// times the size of a real one. It is kept apart from BenchmarkSolvers, which
+// aoc bench runs, because generating and solving the inputs takes a while.
+// Parts that aren't solved are skipped, and a part that fails on an input
+// this size fails the benchmark.
+func BenchmarkLarge(b *testing.B) {
+	for _, puzzle := range solver.Puzzles() {
+		if _, ok := inputgen.Generators[puzzle.Day]; !ok {
+			continue
+		}
+		in, err := inputgen.Generate(puzzle.Day, 1, largeScale)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, part := range solver.Parts {
+			puzzle, part := puzzle, part
+			b.Run(fmt.Sprintf("day%d/part%d", puzzle.Day, part), func(b *testing.B) {
+				_, err := puzzle.Solve(context.Background(), part, strings.NewReader(in))
+				if errors.Is(err, solver.ErrUnsolved) {
+					b.Skip(err)
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.ReportAllocs()
+				b.SetBytes(int64(len(in)))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					puzzle.Solve(context.Background(), part, strings.NewReader(in))
+				}
+			})
+		}
+	}
+}
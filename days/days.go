@@ -0,0 +1,13 @@
+// Package days imports every day so that each registers its solutions with
+// the solver registry.
+package days
+
+import (
+	_ "github.com/scottbarnes/advent-of-code-2023/day1"
+	_ "github.com/scottbarnes/advent-of-code-2023/day2"
+	_ "github.com/scottbarnes/advent-of-code-2023/day3"
+	_ "github.com/scottbarnes/advent-of-code-2023/day4"
+	_ "github.com/scottbarnes/advent-of-code-2023/day5"
+	_ "github.com/scottbarnes/advent-of-code-2023/day6"
+	_ "github.com/scottbarnes/advent-of-code-2023/day7"
+)
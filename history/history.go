@@ -0,0 +1,88 @@
+// Package history keeps the last run of each day and part, so how long a
+// solution takes can be shown without running it again.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Run is the last successful run of a day and part.
+type Run struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration_ns"`
+	Time     time.Time     `json:"time"`
+}
+
+// History is the last Run of every day and part, saved as JSON at Path.
+type History struct {
+	Path string `json:"-"`
+	Runs []Run  `json:"runs"`
+}
+
+// Load reads the History at path. A missing file is an empty History.
+func Load(path string) (*History, error) {
+	history := &History{Path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return history, nil
+}
+
+// Save writes the History back to its Path, sorted by day and part.
+func (h *History) Save() error {
+	sort.Slice(h.Runs, func(i, j int) bool {
+		a, b := h.Runs[i], h.Runs[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		return a.Part < b.Part
+	})
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(h.Path, append(data, '\n'), 0o600)
+}
+
+// Record replaces the last Run of its day and part.
+func (h *History) Record(run Run) {
+	for i := range h.Runs {
+		if h.Runs[i].Day == run.Day && h.Runs[i].Part == run.Part {
+			h.Runs[i] = run
+			return
+		}
+	}
+	h.Runs = append(h.Runs, run)
+}
+
+// Last returns the last Run of a day and part.
+func (h *History) Last(day int, part int) (Run, bool) {
+	for _, run := range h.Runs {
+		if run.Day == day && run.Part == part {
+			return run, true
+		}
+	}
+	return Run{}, false
+}
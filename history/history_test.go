@@ -0,0 +1,44 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	now := time.Date(2023, 12, 6, 6, 0, 0, 0, time.UTC)
+
+	history, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history.Record(Run{Day: 7, Part: 1, Answer: "6440", Duration: time.Millisecond, Time: now})
+	history.Record(Run{Day: 6, Part: 2, Answer: "71503", Duration: time.Second, Time: now})
+	history.Record(Run{Day: 7, Part: 1, Answer: "6441", Duration: 2 * time.Millisecond, Time: now}) // Replaces the first run.
+	if err := history.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Run{
+		{Day: 6, Part: 2, Answer: "71503", Duration: time.Second, Time: now},
+		{Day: 7, Part: 1, Answer: "6441", Duration: 2 * time.Millisecond, Time: now},
+	}
+	if !reflect.DeepEqual(got.Runs, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, got.Runs)
+	}
+
+	if run, ok := got.Last(7, 1); !ok || run.Answer != "6441" {
+		t.Fatalf("Expected the last run of day 7 part 1, but got %v, %v", run, ok)
+	}
+	if _, ok := got.Last(7, 2); ok {
+		t.Fatalf("Expected no run of day 7 part 2")
+	}
+}
@@ -0,0 +1,49 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ParseError reports where a day's input could not be parsed. Line and Column
+// are 1-based, and a Column of 0 means the problem is with the line as a whole.
+type ParseError struct {
+	Day    int
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("day %d: line %d: %s", e.Day, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("day %d: line %d, column %d: %s", e.Day, e.Line, e.Column, e.Msg)
+}
+
+// AtLine sets the line of err if it is a *ParseError, so that a parser for a
+// single line needn't know where the line is in the input. Any other error is
+// returned unchanged.
+func AtLine(err error, line int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Line = line
+	}
+	return err
+}
+
+// Atoi converts s, a field starting at a 1-based column of a line, to an int.
+// It returns a *ParseError for day when s is not a valid int.
+func Atoi(day int, s string, column int) (int, error) {
+	num, err := strconv.Atoi(s)
+	if err != nil {
+		msg := fmt.Sprintf("invalid number %q", s)
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			msg = fmt.Sprintf("%s: %v", msg, numErr.Err)
+		}
+		return 0, &ParseError{Day: day, Column: column, Msg: msg}
+	}
+	return num, nil
+}
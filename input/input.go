@@ -0,0 +1,114 @@
+// Package input reads puzzle inputs into lines, blank-line-separated
+// paragraphs, grids and integer fields. Unlike a default bufio.Scanner, lines
+// may be any length, and read errors are returned rather than dropped.
+package input
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var intRegex = regexp.MustCompile(`-?\d+`)
+
+// Lines returns every line in r without its line ending. As with
+// bufio.ScanLines, a final line without a newline is kept, and a final newline
+// does not add an empty line.
+func Lines(r io.Reader) ([]string, error) {
+	reader := bufio.NewReader(r)
+	lines := []string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			lines = append(lines, line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+		if err != nil {
+			return lines, err
+		}
+	}
+}
+
+// Paragraph is a block of consecutive non-blank lines.
+type Paragraph struct {
+	Line  int // The 1-based line number of the first line.
+	Lines []string
+}
+
+// Paragraphs returns the blocks of lines in r that are separated by one or
+// more blank lines. Blank lines are not included in any paragraph.
+func Paragraphs(r io.Reader) ([]Paragraph, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	paragraphs := []Paragraph{}
+	paragraph := Paragraph{}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(paragraph.Lines) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = Paragraph{}
+			}
+			continue
+		}
+		if len(paragraph.Lines) == 0 {
+			paragraph.Line = i + 1
+		}
+		paragraph.Lines = append(paragraph.Lines, line)
+	}
+
+	if len(paragraph.Lines) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	return paragraphs, nil
+}
+
+// Grid returns the lines in r as rows of bytes. Every row must be the same
+// length as the first, or a *ParseError for day reports the first column
+// where it isn't.
+func Grid(day int, r io.Reader) ([][]byte, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := make([][]byte, len(lines))
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			msg := fmt.Sprintf("row has length %d, expected %d", len(line), len(lines[0]))
+			return nil, &ParseError{Day: day, Line: i + 1, Column: min(len(line), len(lines[0])) + 1, Msg: msg}
+		}
+		grid[i] = []byte(line)
+	}
+
+	return grid, nil
+}
+
+// Ints returns every integer in a line, ignoring any other text. An integer
+// out of range is a *ParseError for day at its column; set its line with
+// AtLine.
+// E.g. "Time:      7  15   30" would return []int{7, 15, 30}.
+func Ints(day int, line string) ([]int, error) {
+	matches := intRegex.FindAllStringIndex(line, -1)
+	nums := make([]int, len(matches))
+	for i, match := range matches {
+		num, err := Atoi(day, line[match[0]:match[1]], match[0]+1)
+		if err != nil {
+			return nil, err
+		}
+		nums[i] = num
+	}
+
+	return nums, nil
+}
@@ -0,0 +1,157 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", []string{}},
+		{"one", []string{"one"}},
+		{"one\ntwo\n", []string{"one", "two"}},
+		{"one\r\ntwo", []string{"one", "two"}},
+		{"one\n\ntwo\n\n", []string{"one", "", "two", ""}},
+	}
+
+	for _, tc := range testCases {
+		got, err := Lines(bytes.NewBufferString(tc.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Expected %q, but got %q for %q", tc.expected, got, tc.input)
+		}
+	}
+}
+
+func TestLinesLongLine(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	got, err := Lines(bytes.NewBufferString(long + "\nshort"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != long || got[1] != "short" {
+		t.Fatalf("Expected a %d byte line and %q, but got %d lines", len(long), "short", len(got))
+	}
+}
+
+func TestLinesError(t *testing.T) {
+	if _, err := Lines(errReader{}); err == nil {
+		t.Fatalf("Expected a read error")
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	input := "seeds: 1 2\n\nseed-to-soil map:\n1 2 3\n4 5 6\n\n\nsoil-to-fertilizer map:\n7 8 9\n"
+	expected := []Paragraph{
+		{1, []string{"seeds: 1 2"}},
+		{3, []string{"seed-to-soil map:", "1 2 3", "4 5 6"}},
+		{8, []string{"soil-to-fertilizer map:", "7 8 9"}},
+	}
+
+	got, err := Paragraphs(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, got)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	got, err := Grid(3, bytes.NewBufferString("467.\n...*\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]byte{[]byte("467."), []byte("...*")}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %q, but got %q", expected, got)
+	}
+
+	testCases := []struct {
+		grid     string
+		expected string
+	}{
+		{"467.\n..\n", "day 3: line 2, column 3: row has length 2, expected 4"},
+		{"467.\n...*\n.....\n", "day 3: line 3, column 5: row has length 5, expected 4"},
+	}
+	for _, tc := range testCases {
+		_, err := Grid(3, bytes.NewBufferString(tc.grid))
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []int
+	}{
+		{"Time:      7  15   30", []int{7, 15, 30}},
+		{"seeds: 79 14 55 13", []int{79, 14, 55, 13}},
+		{"-3 4 -5", []int{-3, 4, -5}},
+		{"no numbers", []int{}},
+	}
+
+	for _, tc := range testCases {
+		got, err := Ints(6, tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Expected %v, but got %v for %q", tc.expected, got, tc.input)
+		}
+	}
+
+	_, err := Ints(6, "Time: 7 99999999999999999999")
+	expected := `day 6: line 2, column 9: invalid number "99999999999999999999": value out of range`
+	if err = AtLine(err, 2); err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{&ParseError{Day: 2, Line: 3, Column: 6, Msg: "expected a number"}, "day 2: line 3, column 6: expected a number"},
+		{&ParseError{Day: 6, Line: 2, Msg: "missing Distance line"}, "day 6: line 2: missing Distance line"},
+		{AtLine(&ParseError{Day: 7, Column: 1, Msg: "bad card"}, 4), "day 7: line 4, column 1: bad card"},
+		{AtLine(errors.New("not a parse error"), 4), "not a parse error"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.err.Error(); got != tc.expected {
+			t.Fatalf("Expected %q, but got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestAtoi(t *testing.T) {
+	got, err := Atoi(5, "79", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 79 {
+		t.Fatalf("Expected %d, but got %d", 79, got)
+	}
+
+	_, err = Atoi(5, "99999999999999999999", 8)
+	expected := `day 5: line 0, column 8: invalid number "99999999999999999999": value out of range`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, but got %v", expected, err)
+	}
+}
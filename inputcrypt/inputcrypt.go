@@ -0,0 +1,250 @@
+// Package inputcrypt keeps puzzle inputs encrypted at rest, so a repository
+// can be published without publishing its inputs. An input at
+// dayN/dayN_input.txt is stored as dayN/dayN_input.txt.enc, sealed with
+// AES-256-GCM under a key that never leaves the machines of the people
+// allowed to read it.
+//
+// The key is 32 bytes, written as 64 hex digits, in $AOC_INPUT_KEY or in the
+// file named by $AOC_INPUT_KEY_FILE.
+package inputcrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Ext is added to the name of an input to name its encrypted copy.
+const Ext = ".enc"
+
+// The environment variables a key is read from.
+const (
+	KeyEnv     = "AOC_INPUT_KEY"
+	KeyFileEnv = "AOC_INPUT_KEY_FILE"
+)
+
+// KeySize is the length of a key in bytes.
+const KeySize = 32
+
+// ErrNoKey is returned when an encrypted input is found but no key is set.
+var ErrNoKey = fmt.Errorf("no input key: set $%s or $%s", KeyEnv, KeyFileEnv)
+
+// ErrDecrypt is returned for an encrypted input that doesn't decrypt: the key
+// is wrong, or the file was changed or truncated.
+var ErrDecrypt = errors.New("wrong key, or the file was changed")
+
+// ErrStale is returned for a plaintext input that differs from its encrypted
+// copy: one of them was changed without the other. Encrypting the input again
+// or decrypting it with force makes them the same.
+var ErrStale = errors.New("differs from its encrypted copy")
+
+// magic starts every encrypted input, and names the format's version.
+var magic = []byte("aoc-input-v1\n")
+
+// GenerateKey returns a new random key, as hex.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// ParseKey decodes a key written as hex. Surrounding space is ignored, so a
+// key file may end in a newline.
+func ParseKey(text string) ([]byte, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("input key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("input key: expected %d bytes, but got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads a key from a file.
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParseKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// LoadKey returns the key set in $AOC_INPUT_KEY or, failing that, the file
+// named by $AOC_INPUT_KEY_FILE. It returns ErrNoKey if neither is set.
+func LoadKey() ([]byte, error) {
+	if text := os.Getenv(KeyEnv); text != "" {
+		return ParseKey(text)
+	}
+	if path := os.Getenv(KeyFileEnv); path != "" {
+		return ReadKeyFile(path)
+	}
+	return nil, ErrNoKey
+}
+
+// Seal encrypts an input. name, the input's file name, is authenticated with
+// it, so one day's encrypted input can't be passed off as another's.
+func Seal(key []byte, name string, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte{}, magic...), nonce...)
+	return aead.Seal(sealed, nonce, plaintext, []byte(name)), nil
+}
+
+// Open decrypts an input sealed under key with the same name.
+func Open(key []byte, name string, sealed []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(sealed, magic) || len(sealed) < len(magic)+aead.NonceSize() {
+		return nil, errors.New("not an encrypted input")
+	}
+	sealed = sealed[len(magic):]
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// newAEAD returns AES-GCM under key, which must be KeySize bytes for AES-256.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ReadFile reads the input at path. If it is kept encrypted, its encrypted
+// copy at path+Ext is decrypted with the key from LoadKey and read instead,
+// and a plaintext input beside it must hold the same input, or ReadFile
+// returns ErrStale rather than read either.
+func ReadFile(path string) ([]byte, error) {
+	sealed, err := os.ReadFile(path + Ext)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := LoadKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path+Ext, err)
+	}
+	plaintext, err := Open(key, filepath.Base(path), sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path+Ext, err)
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil && !bytes.Equal(current, plaintext) {
+		return nil, fmt.Errorf("%s: %w", path, ErrStale)
+	}
+	return plaintext, nil
+}
+
+// Exists reports whether the input at path, or its encrypted copy, exists.
+func Exists(path string) bool {
+	for _, name := range []string{path, path + Ext} {
+		if _, err := os.Stat(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// EncryptFile encrypts the input at path to path+Ext. It leaves an encrypted
+// copy that already holds the same input alone, so that encrypting again
+// doesn't change every file, and reports whether it wrote one.
+func EncryptFile(key []byte, path string) (bool, error) {
+	plaintext, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	name := filepath.Base(path)
+	if sealed, err := os.ReadFile(path + Ext); err == nil {
+		if current, err := Open(key, name, sealed); err == nil && bytes.Equal(current, plaintext) {
+			return false, nil
+		}
+	}
+
+	sealed, err := Seal(key, name, plaintext)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path+Ext, sealed, 0o644)
+}
+
+// DecryptFile decrypts path+Ext to the input at path, and reports whether it
+// wrote it. An input that differs from its encrypted copy is only replaced
+// with force.
+func DecryptFile(key []byte, path string, force bool) (bool, error) {
+	sealed, err := os.ReadFile(path + Ext)
+	if err != nil {
+		return false, err
+	}
+	plaintext, err := Open(key, filepath.Base(path), sealed)
+	if err != nil {
+		return false, err
+	}
+
+	if current, err := os.ReadFile(path); err == nil {
+		if bytes.Equal(current, plaintext) {
+			return false, nil
+		}
+		if !force {
+			return false, fmt.Errorf("%s differs from %s", path, path+Ext)
+		}
+	}
+	return true, os.WriteFile(path, plaintext, 0o600)
+}
+
+// RekeyFile encrypts path+Ext again under newKey.
+func RekeyFile(oldKey []byte, newKey []byte, path string) error {
+	name := filepath.Base(path)
+	sealed, err := os.ReadFile(path + Ext)
+	if err != nil {
+		return err
+	}
+	plaintext, err := Open(oldKey, name, sealed)
+	if err != nil {
+		return err
+	}
+
+	if sealed, err = Seal(newKey, name, plaintext); err != nil {
+		return err
+	}
+	return os.WriteFile(path+Ext, sealed, 0o644)
+}
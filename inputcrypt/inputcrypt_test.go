@@ -0,0 +1,252 @@
+package inputcrypt
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newKey returns a new key, as bytes and as hex.
+func newKey(t *testing.T) ([]byte, string) {
+	t.Helper()
+	text, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseKey(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, text
+}
+
+func TestSealOpen(t *testing.T) {
+	key, _ := newKey(t)
+	otherKey, _ := newKey(t)
+	plaintext := []byte("1abc2\npqr3stu8vwx\n")
+
+	sealed, err := Seal(key, "day1_input.txt", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Fatalf("Expected the input to be encrypted, but got %q", sealed)
+	}
+
+	got, err := Open(key, "day1_input.txt", sealed)
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Expected %q, but got %q, %v", plaintext, got, err)
+	}
+
+	tests := []struct {
+		name   string
+		key    []byte
+		file   string
+		sealed []byte
+	}{
+		{"wrong key", otherKey, "day1_input.txt", sealed},
+		{"wrong name", key, "day2_input.txt", sealed},
+		{"changed", key, "day1_input.txt", append(append([]byte{}, sealed[:len(sealed)-1]...), sealed[len(sealed)-1]^1)},
+		{"truncated", key, "day1_input.txt", sealed[:len(magic)+4]},
+		{"plaintext", key, "day1_input.txt", plaintext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Open(tt.key, tt.file, tt.sealed); err == nil {
+				t.Fatalf("Expected an error, but got %q", got)
+			}
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"not hex", strings.Repeat("g", 64), "input key: encoding/hex: invalid byte: U+0067 'g'"},
+		{"short", strings.Repeat("ab", 16), "input key: expected 32 bytes, but got 16"},
+		{"empty", "", "input key: expected 32 bytes, but got 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKey(tt.text)
+			if err == nil || err.Error() != tt.expected {
+				t.Fatalf("Expected %q, but got %v", tt.expected, err)
+			}
+		})
+	}
+
+	if _, err := ParseKey(strings.Repeat("ab", 32) + "\n"); err != nil {
+		t.Fatalf("Expected a key with a trailing newline to parse, but got %v", err)
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	key, text := newKey(t)
+	fileKey, fileText := newKey(t)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte(fileText+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, "")
+	if _, err := LoadKey(); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("Expected %v, but got %v", ErrNoKey, err)
+	}
+
+	t.Setenv(KeyFileEnv, keyFile)
+	if got, err := LoadKey(); err != nil || !bytes.Equal(got, fileKey) {
+		t.Fatalf("Expected the key from the key file, but got %x, %v", got, err)
+	}
+
+	t.Setenv(KeyEnv, text)
+	if got, err := LoadKey(); err != nil || !bytes.Equal(got, key) {
+		t.Fatalf("Expected the key from $%s, but got %x, %v", KeyEnv, got, err)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	key, text := newKey(t)
+	path := filepath.Join(t.TempDir(), "day1_input.txt")
+	plaintext := []byte("two1nine\n")
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, "")
+
+	if _, err := ReadFile(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected %v, but got %v", fs.ErrNotExist, err)
+	}
+	if Exists(path) {
+		t.Fatalf("Expected %s not to exist", path)
+	}
+
+	if err := os.WriteFile(path, plaintext, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EncryptFile(key, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if !Exists(path) {
+		t.Fatalf("Expected %s to exist encrypted", path)
+	}
+
+	if _, err := ReadFile(path); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("Expected %v, but got %v", ErrNoKey, err)
+	}
+
+	t.Setenv(KeyEnv, text)
+	if got, err := ReadFile(path); err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Expected %q, but got %q, %v", plaintext, got, err)
+	}
+
+	_, otherText := newKey(t)
+	t.Setenv(KeyEnv, otherText)
+	if _, err := ReadFile(path); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("Expected %v, but got %v", ErrDecrypt, err)
+	}
+}
+
+func TestReadFileBoth(t *testing.T) {
+	key, text := newKey(t)
+	path := filepath.Join(t.TempDir(), "day1_input.txt")
+	plaintext := []byte("two1nine\n")
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, "")
+
+	if err := os.WriteFile(path, plaintext, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EncryptFile(key, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// The encrypted copy is what is read, so it needs the key even with the
+	// plaintext beside it.
+	if _, err := ReadFile(path); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("Expected %v, but got %v", ErrNoKey, err)
+	}
+
+	t.Setenv(KeyEnv, text)
+	if got, err := ReadFile(path); err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Expected %q, but got %q, %v", plaintext, got, err)
+	}
+
+	if err := os.WriteFile(path, []byte("eightwo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadFile(path); !errors.Is(err, ErrStale) {
+		t.Fatalf("Expected %v, but got %q, %v", ErrStale, got, err)
+	}
+
+	if _, err := EncryptFile(key, path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadFile(path); err != nil || string(got) != "eightwo\n" {
+		t.Fatalf("Expected the input encrypted again, but got %q, %v", got, err)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	key, _ := newKey(t)
+	newerKey, _ := newKey(t)
+	path := filepath.Join(t.TempDir(), "day2_input.txt")
+	plaintext := []byte("Game 1: 3 blue, 4 red\n")
+	if err := os.WriteFile(path, plaintext, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if wrote, err := EncryptFile(key, path); err != nil || !wrote {
+		t.Fatalf("Expected the input to be encrypted, but got %v, %v", wrote, err)
+	}
+	sealed, err := os.ReadFile(path + Ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrote, err := EncryptFile(key, path); err != nil || wrote {
+		t.Fatalf("Expected an unchanged input to be left alone, but got %v, %v", wrote, err)
+	}
+	if again, _ := os.ReadFile(path + Ext); !bytes.Equal(again, sealed) {
+		t.Fatalf("Expected the encrypted input to be unchanged")
+	}
+
+	if wrote, err := DecryptFile(key, path, false); err != nil || wrote {
+		t.Fatalf("Expected a matching input to be left alone, but got %v, %v", wrote, err)
+	}
+	if err := os.WriteFile(path, []byte("edited\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptFile(key, path, false); err == nil {
+		t.Fatalf("Expected an edited input not to be replaced without force")
+	}
+	if wrote, err := DecryptFile(key, path, true); err != nil || !wrote {
+		t.Fatalf("Expected the input to be replaced with force, but got %v, %v", wrote, err)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, plaintext) {
+		t.Fatalf("Expected %q, but got %q", plaintext, got)
+	}
+
+	if err := RekeyFile(newerKey, key, path); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("Expected %v, but got %v", ErrDecrypt, err)
+	}
+	if err := RekeyFile(key, newerKey, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if wrote, err := DecryptFile(newerKey, path, false); err != nil || !wrote {
+		t.Fatalf("Expected the input to decrypt under the new key, but got %v, %v", wrote, err)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, plaintext) {
+		t.Fatalf("Expected %q, but got %q", plaintext, got)
+	}
+}
@@ -0,0 +1,316 @@
+// Package inputgen generates valid puzzle inputs of any size for each day, for
+// stress tests, property tests and benchmarks bigger than the real inputs.
+//
+// Every generator takes a scale: 1 is about the size of a real input and each
+// step adds another real input's worth of lines, except for day 6, whose race
+// sheet can't grow (see Races). Small inputs, a few lines of small numbers,
+// are for checking solvers against brute force. The same seed always gives
+// the same input.
+package inputgen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Generator returns an input at scale drawn from r.
+type Generator func(r *rand.Rand, scale int) string
+
+// Generators are keyed by day.
+var Generators = map[int]Generator{
+	1: Calibration,
+	2: CubeGames,
+	3: Schematic,
+	4: Scratchcards,
+	5: Almanac,
+	6: Races,
+	7: CamelHands,
+}
+
+// Generate returns an input for a day at scale, drawn from seed.
+func Generate(day int, seed int64, scale int) (string, error) {
+	generate, ok := Generators[day]
+	if !ok {
+		return "", fmt.Errorf("no generator for day %d", day)
+	}
+	if scale < 1 {
+		return "", fmt.Errorf("scale %d is less than 1", scale)
+	}
+	return generate(rand.New(rand.NewSource(seed)), scale), nil
+}
+
+// smallGenerators return inputs small enough to solve by brute force, keyed
+// by day.
+var smallGenerators = map[int]func(r *rand.Rand) string{
+	1: func(r *rand.Rand) string { return calibration(r, 1+r.Intn(10)) },
+	2: func(r *rand.Rand) string { return cubeGames(r, 1+r.Intn(10)) },
+	3: func(r *rand.Rand) string { return schematic(r, 5+r.Intn(10), 1+r.Intn(10)) },
+	4: func(r *rand.Rand) string { return scratchcards(r, 1+r.Intn(10)) },
+	5: func(r *rand.Rand) string { return almanac(r, 1+r.Intn(3), 1+r.Intn(5), 100, 20) },
+	6: func(r *rand.Rand) string { return races(r, 1+r.Intn(3), 30) },
+	7: func(r *rand.Rand) string { return camelHands(r, 1+r.Intn(20), "29TJQA") },
+}
+
+// Small returns a small input for a day, drawn from seed. Its numbers are
+// small too, e.g. an almanac's are below 100, and hands are drawn from fewer
+// cards so they have more pairs and jokers.
+func Small(day int, seed int64) (string, error) {
+	generate, ok := smallGenerators[day]
+	if !ok {
+		return "", fmt.Errorf("no generator for day %d", day)
+	}
+	return generate(rand.New(rand.NewSource(seed))), nil
+}
+
+var (
+	numberWords     = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+	overlappingWord = []string{"oneight", "twone", "threeight", "fiveight", "sevenine", "eightwo", "eighthree", "nineight"}
+)
+
+// Calibration returns day 1 calibration lines: letters, digits, number words
+// and number words that overlap, e.g. "twone". Every line has a digit.
+func Calibration(r *rand.Rand, scale int) string {
+	return calibration(r, 1000*scale)
+}
+
+func calibration(r *rand.Rand, lines int) string {
+	var b strings.Builder
+	for i := 0; i < lines; i++ {
+		tokens := make([]string, 2+r.Intn(7))
+		for j := range tokens {
+			switch r.Intn(4) {
+			case 0:
+				tokens[j] = strconv.Itoa(1 + r.Intn(9))
+			case 1:
+				tokens[j] = numberWords[r.Intn(len(numberWords))]
+			case 2:
+				tokens[j] = overlappingWord[r.Intn(len(overlappingWord))]
+			default:
+				tokens[j] = letters(r, 1+r.Intn(4))
+			}
+		}
+		tokens[r.Intn(len(tokens))] = strconv.Itoa(1 + r.Intn(9))
+		b.WriteString(strings.Join(tokens, "") + "\n")
+	}
+	return b.String()
+}
+
+var cubeColors = []string{"red", "green", "blue"}
+
+// CubeGames returns day 2 games of one to six draws of up to 20 cubes of each
+// color.
+func CubeGames(r *rand.Rand, scale int) string {
+	return cubeGames(r, 100*scale)
+}
+
+func cubeGames(r *rand.Rand, games int) string {
+	var b strings.Builder
+	for game := 1; game <= games; game++ {
+		draws := make([]string, 1+r.Intn(6))
+		for i := range draws {
+			colors := r.Perm(len(cubeColors))[:1+r.Intn(len(cubeColors))]
+			cubes := make([]string, len(colors))
+			for j, color := range colors {
+				cubes[j] = fmt.Sprintf("%d %s", 1+r.Intn(20), cubeColors[color])
+			}
+			draws[i] = strings.Join(cubes, ", ")
+		}
+		fmt.Fprintf(&b, "Game %d: %s\n", game, strings.Join(draws, "; "))
+	}
+	return b.String()
+}
+
+const schematicSymbols = "*#+$/@=%&-"
+
+// Schematic returns a day 3 engine schematic 140 columns wide and 140 rows
+// high per scale, of numbers up to three digits, symbols and dots.
+func Schematic(r *rand.Rand, scale int) string {
+	return schematic(r, 140, 140*scale)
+}
+
+func schematic(r *rand.Rand, width int, rows int) string {
+	var b strings.Builder
+	for row := 0; row < rows; row++ {
+		line := make([]byte, 0, width)
+		for len(line) < width {
+			switch n := r.Intn(20); {
+			case n < 3 && len(line)+3 < width:
+				line = append(line, strconv.Itoa(1+r.Intn(999))...)
+				line = append(line, '.')
+			case n < 4:
+				line = append(line, schematicSymbols[r.Intn(len(schematicSymbols))])
+			default:
+				line = append(line, '.')
+			}
+		}
+		b.Write(line[:width])
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Scratchcards returns day 4 cards of 10 winning numbers and 25 of your
+// numbers, each from 1 to 99. Cards have few matches, as in the real inputs,
+// so the copies of part 2 grow slowly, and never win copies of cards past the
+// end of the table.
+func Scratchcards(r *rand.Rand, scale int) string {
+	return scratchcards(r, 200*scale)
+}
+
+func scratchcards(r *rand.Rand, cards int) string {
+	width := len(strconv.Itoa(cards))
+
+	var b strings.Builder
+	for card := 1; card <= cards; card++ {
+		matches := 0
+		if r.Intn(2) == 0 {
+			matches = 1 + r.Intn(2)
+		}
+		if remaining := cards - card; matches > remaining {
+			matches = remaining
+		}
+
+		numbers := r.Perm(99)
+		winning := numbers[:10]
+		yours := append(append([]int{}, winning[:matches]...), numbers[10:10+25-matches]...)
+		r.Shuffle(len(yours), func(i, j int) { yours[i], yours[j] = yours[j], yours[i] })
+
+		fmt.Fprintf(&b, "Card %*d: %s | %s\n", width, card, cardNumbers(winning), cardNumbers(yours))
+	}
+	return b.String()
+}
+
+// cardNumbers formats the zero-based numbers of a card two characters wide.
+func cardNumbers(numbers []int) string {
+	formatted := make([]string, len(numbers))
+	for i, n := range numbers {
+		formatted[i] = fmt.Sprintf("%2d", n+1)
+	}
+	return strings.Join(formatted, " ")
+}
+
+var almanacMaps = []string{
+	"seed-to-soil",
+	"soil-to-fertilizer",
+	"fertilizer-to-water",
+	"water-to-light",
+	"light-to-temperature",
+	"temperature-to-humidity",
+	"humidity-to-location",
+}
+
+// Almanac returns a day 5 almanac of 10 seed ranges per scale and the seven
+// maps, each of up to 40 mappings per scale whose source ranges don't
+// overlap. Numbers are below 2^32, as in the real inputs.
+func Almanac(r *rand.Rand, scale int) string {
+	return almanac(r, 10*scale, (10+r.Intn(30))*scale, 1<<32, 1<<28)
+}
+
+// almanac returns an almanac of seedRanges ranges of up to maxSeedRange seeds,
+// and maps of up to mappings mappings, with every number below limit.
+func almanac(r *rand.Rand, seedRanges int, mappings int, limit int64, maxSeedRange int64) string {
+	var b strings.Builder
+	seeds := make([]string, 0, 2*seedRanges)
+	for i := 0; i < seedRanges; i++ {
+		start := r.Int63n(limit - maxSeedRange)
+		seeds = append(seeds, strconv.FormatInt(start, 10), strconv.FormatInt(1+r.Int63n(maxSeedRange), 10))
+	}
+	fmt.Fprintf(&b, "seeds: %s\n", strings.Join(seeds, " "))
+
+	for _, name := range almanacMaps {
+		fmt.Fprintf(&b, "\n%s map:\n", name)
+
+		// Cut [0, limit) at random points and map every other piece.
+		count := 2 * (1 + r.Intn(mappings))
+		cuts := map[int64]bool{}
+		for len(cuts) < count {
+			cuts[r.Int63n(limit)] = true
+		}
+		sorted := make([]int64, 0, len(cuts))
+		for cut := range cuts {
+			sorted = append(sorted, cut)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		for i := 0; i+1 < len(sorted); i += 2 {
+			source, length := sorted[i], sorted[i+1]-sorted[i]
+			destination := r.Int63n(limit - length)
+			fmt.Fprintf(&b, "%d %d %d\n", destination, source, length)
+		}
+	}
+	return b.String()
+}
+
+// Races returns a day 6 race sheet of four races, each of which can be won,
+// at every scale. A sheet can't grow: part 1 multiplies every race's ways to
+// win, and part 2 joins every race's digits into one race, and with more
+// races either overflows an int. Four races under 100, as in a real sheet,
+// join to a time of eight digits and a record of at most sixteen.
+func Races(r *rand.Rand, scale int) string {
+	return races(r, 4, 100)
+}
+
+// races returns a sheet of count races, each shorter than maxTime.
+func races(r *rand.Rand, count int, maxTime int) string {
+	times := make([]int, count)
+	records := make([]int, count)
+	for i := range times {
+		times[i] = 7 + r.Intn(maxTime-7)
+		best := (times[i] / 2) * (times[i] - times[i]/2)
+		records[i] = best/2 + r.Intn(best-best/2)
+	}
+
+	var timeLine, distanceLine strings.Builder
+	timeLine.WriteString("Time:    ")
+	distanceLine.WriteString("Distance:")
+	for i := range times {
+		width := len(strconv.Itoa(records[i])) + 2
+		fmt.Fprintf(&timeLine, "%*d", width, times[i])
+		fmt.Fprintf(&distanceLine, "%*d", width, records[i])
+	}
+	return timeLine.String() + "\n" + distanceLine.String() + "\n"
+}
+
+const camelCards = "23456789TJQKA"
+
+// CamelHands returns day 7 hands of five cards, each with a bid up to 1000.
+// No two hands are the same, as in the real inputs, so their ranks are never
+// tied.
+func CamelHands(r *rand.Rand, scale int) string {
+	return camelHands(r, 1000*scale, camelCards)
+}
+
+// camelHands returns up to count distinct hands drawn from cards.
+func camelHands(r *rand.Rand, count int, cards string) string {
+	if possible := int(math.Pow(float64(len(cards)), 5)); count > possible {
+		count = possible
+	}
+
+	var b strings.Builder
+	seen := map[string]bool{}
+	for len(seen) < count {
+		hand := make([]byte, 5)
+		for j := range hand {
+			hand[j] = cards[r.Intn(len(cards))]
+		}
+		if seen[string(hand)] {
+			continue
+		}
+		seen[string(hand)] = true
+		fmt.Fprintf(&b, "%s %d\n", hand, 1+r.Intn(1000))
+	}
+	return b.String()
+}
+
+// letters returns n random lowercase letters.
+func letters(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return string(b)
+}
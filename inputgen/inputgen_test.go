@@ -0,0 +1,217 @@
+package inputgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+
+	_ "github.com/scottbarnes/advent-of-code-2023/days"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for day := range Generators {
+		first, err := Generate(day, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := Generate(day, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := Generate(day, 2, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if first != second {
+			t.Fatalf("day %d: expected the same input for the same seed", day)
+		}
+		if first == other {
+			t.Fatalf("day %d: expected different inputs for different seeds", day)
+		}
+	}
+}
+
+func TestSmall(t *testing.T) {
+	for day := range Generators {
+		first, err := Small(day, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := Small(day, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != second {
+			t.Fatalf("day %d: expected the same input for the same seed", day)
+		}
+
+		large, err := Generate(day, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(first) >= len(large) {
+			t.Fatalf("day %d: expected a small input to be smaller than a real one", day)
+		}
+	}
+
+	if _, err := Small(99, 1); err == nil {
+		t.Fatalf("Expected an error for a day without a generator")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	if _, err := Generate(99, 1, 1); err == nil {
+		t.Fatalf("Expected an error for a day without a generator")
+	}
+	if _, err := Generate(1, 1, 0); err == nil {
+		t.Fatalf("Expected an error for scale 0")
+	}
+}
+
+func TestScale(t *testing.T) {
+	testCases := []struct {
+		day   int
+		lines int
+	}{
+		{1, 1000},
+		{2, 100},
+		{3, 140},
+		{4, 200},
+		{6, 2},
+		{7, 1000},
+	}
+
+	for _, tc := range testCases {
+		for _, scale := range []int{1, 3} {
+			in, err := Generate(tc.day, 1, scale)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := tc.lines * scale
+			if tc.day == 6 {
+				expected = tc.lines
+			}
+			if got := strings.Count(in, "\n"); got != expected {
+				t.Fatalf("day %d at scale %d: expected %d lines, but got %d", tc.day, scale, expected, got)
+			}
+		}
+	}
+}
+
+// TestSolversAcceptInputs solves both parts of many generated inputs for
+// every day, which must parse and solve without error.
+func TestSolversAcceptInputs(t *testing.T) {
+	for day := range Generators {
+		puzzle, err := solver.Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for seed := int64(1); seed <= 5; seed++ {
+			in, err := Generate(day, seed, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, part := range solver.Parts {
+				t.Run(fmt.Sprintf("day%d/seed%d/part%d", day, seed, part), func(t *testing.T) {
+					_, err := puzzle.Solve(context.Background(), part, strings.NewReader(in))
+					if errors.Is(err, solver.ErrUnsolved) {
+						t.Skip(err)
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRacesFitAtAnyScale(t *testing.T) {
+	puzzle, err := solver.Lookup(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scale := range []int{1, 100, 10000} {
+		in, err := Generate(6, 1, scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, part := range solver.Parts {
+			got, err := puzzle.Solve(context.Background(), part, strings.NewReader(in))
+			if err != nil || strings.HasPrefix(got, "-") {
+				t.Fatalf("day 6 part %d at scale %d: expected an answer, but got %q, %v", part, scale, got, err)
+			}
+		}
+	}
+}
+
+func TestCalibrationLinesHaveDigits(t *testing.T) {
+	digit := regexp.MustCompile(`[1-9]`)
+	for _, line := range strings.Split(strings.TrimSpace(Calibration(newRand(1), 1)), "\n") {
+		if !digit.MatchString(line) {
+			t.Fatalf("Expected a digit in %q", line)
+		}
+	}
+}
+
+func TestScratchcardsStayOnTheTable(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(Scratchcards(newRand(1), 1)), "\n")
+	for i, line := range lines {
+		_, numbers, _ := strings.Cut(line, ": ")
+		winning, yours, _ := strings.Cut(numbers, " | ")
+
+		isWinning := map[string]bool{}
+		for _, n := range strings.Fields(winning) {
+			isWinning[n] = true
+		}
+		matches := 0
+		for _, n := range strings.Fields(yours) {
+			if isWinning[n] {
+				matches++
+			}
+		}
+
+		if len(isWinning) != 10 || len(strings.Fields(yours)) != 25 {
+			t.Fatalf("Expected 10 distinct winning numbers and 25 of yours in %q", line)
+		}
+		if i+matches >= len(lines) {
+			t.Fatalf("Card %d wins copies past the end of the table", i+1)
+		}
+	}
+}
+
+func TestAlmanacSourcesDoNotOverlap(t *testing.T) {
+	mapping := regexp.MustCompile(`^(\d+) (\d+) (\d+)$`)
+	for _, section := range strings.Split(Almanac(newRand(1), 2), "\n\n")[1:] {
+		var ends [][2]int64
+		for _, line := range strings.Split(strings.TrimSpace(section), "\n")[1:] {
+			m := mapping.FindStringSubmatch(line)
+			if m == nil {
+				t.Fatalf("Expected a mapping, but got %q", line)
+			}
+			var source, length int64
+			fmt.Sscan(m[2], &source)
+			fmt.Sscan(m[3], &length)
+			for _, other := range ends {
+				if source < other[1] && other[0] < source+length {
+					t.Fatalf("Expected %q not to overlap another mapping", line)
+				}
+			}
+			ends = append(ends, [2]int64{source, source + length})
+		}
+	}
+}
+
+func newRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,202 @@
+// Package bench runs the solver benchmarks at a git revision and compares the
+// results of two runs.
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Package is the package holding the solver benchmarks, and File the file
+// they live in.
+const (
+	Package = "./days"
+	File    = "days/bench_test.go"
+)
+
+// legacyFile is File as it was first written, for revisions whose solvers
+// don't take a context.
+//
+//go:embed testdata/legacy_bench_test.go.txt
+var legacyFile []byte
+
+// Result is one benchmark, averaged over every run of it.
+type Result struct {
+	NsPerOp     float64
+	BytesPerOp  float64
+	AllocsPerOp float64
+	runs        int
+}
+
+// Results are keyed by benchmark name, without the GOMAXPROCS suffix.
+type Results map[string]*Result
+
+var (
+	lineRegex   = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+(.*)$`)
+	metricRegex = regexp.MustCompile(`([\d.]+) (ns/op|B/op|allocs/op)`)
+)
+
+// Parse reads the output of go test -bench -benchmem.
+func Parse(r io.Reader) (Results, error) {
+	results := Results{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		match := lineRegex.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		result := results[match[1]]
+		if result == nil {
+			result = &Result{}
+			results[match[1]] = result
+		}
+		result.runs++
+
+		for _, metric := range metricRegex.FindAllStringSubmatch(match[2], -1) {
+			value, err := strconv.ParseFloat(metric[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", match[1], err)
+			}
+			switch metric[2] {
+			case "ns/op":
+				result.NsPerOp += (value - result.NsPerOp) / float64(result.runs)
+			case "B/op":
+				result.BytesPerOp += (value - result.BytesPerOp) / float64(result.runs)
+			case "allocs/op":
+				result.AllocsPerOp += (value - result.AllocsPerOp) / float64(result.runs)
+			}
+		}
+	}
+
+	return results, scanner.Err()
+}
+
+// Run runs the benchmarks whose day/part/input names match pattern, count
+// times, in dir and returns the results. The output of go test is copied to
+// log.
+func Run(dir string, pattern string, count int, log io.Writer) (Results, error) {
+	var out strings.Builder
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", "^BenchmarkSolvers$/"+pattern, "-benchmem", "-count", strconv.Itoa(count), Package)
+	cmd.Dir = dir
+	cmd.Stdout = io.MultiWriter(&out, log)
+	cmd.Stderr = log
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go test in %s: %w", dir, err)
+	}
+
+	return Parse(strings.NewReader(out.String()))
+}
+
+// Checkout checks out a git revision into a temporary worktree and returns its
+// directory and a function that removes it. A revision from before the
+// benchmarks existed gets a File for its solver API: the current one, or for
+// solvers without a context, the first one. A revision from before
+// solver.Puzzles can't be benchmarked.
+func Checkout(rev string) (string, func(), error) {
+	tmp, err := os.MkdirTemp("", "aoc-bench-")
+	if err != nil {
+		return "", nil, err
+	}
+	dir := filepath.Join(tmp, "tree")
+	cleanup := func() {
+		exec.Command("git", "worktree", "remove", "--force", dir).Run()
+		os.RemoveAll(tmp)
+	}
+
+	if out, err := exec.Command("git", "worktree", "add", "--detach", dir, rev).CombinedOutput(); err != nil {
+		os.RemoveAll(tmp)
+		return "", nil, fmt.Errorf("git worktree add %s: %w: %s", rev, err, out)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, File)); errors.Is(err, os.ErrNotExist) {
+		data, err := benchFile(dir)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, File), data, 0o644)
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("%s: %w", rev, err)
+		}
+	}
+
+	return dir, cleanup, nil
+}
+
+// benchFile returns the File that builds against the solver API of the tree
+// in dir.
+func benchFile(dir string) ([]byte, error) {
+	api, err := os.ReadFile(filepath.Join(dir, "solver", "solver.go"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	switch {
+	case !bytes.Contains(api, []byte("func Puzzles()")):
+		return nil, errors.New("predates solver.Puzzles, which the benchmarks need")
+	case !bytes.Contains(api, []byte("Solve(ctx context.Context")):
+		return legacyFile, nil
+	default:
+		return os.ReadFile(File)
+	}
+}
+
+// WriteTable writes the results of every benchmark in either run side by side,
+// with the change in time and allocations.
+func WriteTable(w io.Writer, old Results, new Results) error {
+	names := map[string]bool{}
+	for name := range old {
+		names[name] = true
+	}
+	for name := range new {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tOLD NS/OP\tNEW NS/OP\tDELTA\tOLD ALLOCS/OP\tNEW ALLOCS/OP\tDELTA")
+	for _, name := range sorted {
+		o, n := old[name], new[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			strings.TrimPrefix(name, "BenchmarkSolvers/"),
+			format(o, nsPerOp), format(n, nsPerOp), delta(o, n, nsPerOp),
+			format(o, allocsPerOp), format(n, allocsPerOp), delta(o, n, allocsPerOp))
+	}
+	return tw.Flush()
+}
+
+func nsPerOp(r *Result) float64     { return r.NsPerOp }
+func allocsPerOp(r *Result) float64 { return r.AllocsPerOp }
+
+// format returns a metric of a result, or "-" if there is no result.
+func format(r *Result, metric func(*Result) float64) string {
+	if r == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(metric(r), 'f', 0, 64)
+}
+
+// delta returns the change in a metric from old to new as a percentage.
+func delta(old *Result, new *Result, metric func(*Result) float64) string {
+	if old == nil || new == nil || metric(old) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (metric(new)-metric(old))/metric(old)*100)
+}
@@ -0,0 +1,113 @@
+package bench
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: github.com/scottbarnes/advent-of-code-2023/days
+BenchmarkSolvers/day3/part1/real-8         	      50	  20000000 ns/op	 7122682 B/op	   77672 allocs/op
+BenchmarkSolvers/day3/part1/real-8         	      50	  22000000 ns/op	 7122682 B/op	   77670 allocs/op
+BenchmarkSolvers/day6/part1/example        	  300000	      3495 ns/op	    4821 B/op	      24 allocs/op
+PASS
+ok  	github.com/scottbarnes/advent-of-code-2023/days	2.139s
+`
+
+func TestParse(t *testing.T) {
+	results, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected %d results, but got %d", 2, len(results))
+	}
+
+	testCases := []struct {
+		name     string
+		expected Result
+	}{
+		{"BenchmarkSolvers/day3/part1/real", Result{21000000, 7122682, 77671, 2}},
+		{"BenchmarkSolvers/day6/part1/example", Result{3495, 4821, 24, 1}},
+	}
+	for _, tc := range testCases {
+		got := results[tc.name]
+		if got == nil || *got != tc.expected {
+			t.Fatalf("%s: expected %+v, but got %+v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	old := Results{
+		"BenchmarkSolvers/day3/part1/real": {NsPerOp: 2000, AllocsPerOp: 100},
+		"BenchmarkSolvers/day4/part1/real": {NsPerOp: 500, AllocsPerOp: 10},
+	}
+	new := Results{
+		"BenchmarkSolvers/day3/part1/real": {NsPerOp: 1000, AllocsPerOp: 150},
+		"BenchmarkSolvers/day8/part1/real": {NsPerOp: 700, AllocsPerOp: 7},
+	}
+
+	var got strings.Builder
+	if err := WriteTable(&got, old, new); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `NAME             OLD NS/OP  NEW NS/OP  DELTA   OLD ALLOCS/OP  NEW ALLOCS/OP  DELTA
+day3/part1/real  2000       1000       -50.0%  100            150            +50.0%
+day4/part1/real  500        -          -       10             -              -
+day8/part1/real  -          700        -       -              7              -
+`
+	if got.String() != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, got.String())
+	}
+}
+
+func TestBenchFile(t *testing.T) {
+	testCases := []struct {
+		name   string
+		api    string
+		legacy bool
+		err    bool
+	}{
+		{"context", "func Puzzles() []Puzzle\nfunc (p Puzzle) Solve(ctx context.Context, part int, r io.Reader)", false, false},
+		{"no context", "func Puzzles() []Puzzle\nfunc (p Puzzle) Solve(part int, r io.Reader)", true, false},
+		{"no puzzles", "func Lookup(day int, part int) (RunFunc, error)", false, true},
+		{"no solver", "", false, true},
+	}
+
+	// The current File is read from the root of the repository.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	for _, tc := range testCases {
+		dir := t.TempDir()
+		if tc.api != "" {
+			if err := os.Mkdir(filepath.Join(dir, "solver"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "solver", "solver.go"), []byte(tc.api), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		data, err := benchFile(dir)
+		if (err != nil) != tc.err {
+			t.Fatalf("%s: expected an error %v, but got %v", tc.name, tc.err, err)
+		}
+		if err == nil && bytes.Equal(data, legacyFile) != tc.legacy {
+			t.Fatalf("%s: expected the first File %v", tc.name, tc.legacy)
+		}
+	}
+}
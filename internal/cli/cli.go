@@ -0,0 +1,209 @@
+// Package cli holds what the aoc command and the single-day commands share.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/cache"
+	"github.com/scottbarnes/advent-of-code-2023/history"
+	"github.com/scottbarnes/advent-of-code-2023/inputcrypt"
+	"github.com/scottbarnes/advent-of-code-2023/internal/runner"
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// OpenInput opens the input for a day: stdin for "-", the given path, or the
+// cached input when no path is given. A missing input is fetched with the
+// session in $AOC_SESSION or, failing that, read from the copy committed in
+// the repository when run from its root. A file that only exists encrypted
+// is decrypted with the input key.
+func OpenInput(day int, path string) (io.ReadCloser, error) {
+	switch path {
+	case "-":
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		store, err := InputStore()
+		if err != nil {
+			return nil, err
+		}
+
+		reader, err := store.Open(day)
+		if errors.Is(err, cache.ErrNotCached) {
+			committed := CommittedInput(day)
+			if !inputcrypt.Exists(committed) {
+				return nil, fmt.Errorf("%w: set $AOC_SESSION or pass -input", err)
+			}
+			path = committed
+		} else {
+			return reader, err
+		}
+	}
+
+	data, err := inputcrypt.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// CommittedInput returns where a day's input is committed in the repository,
+// relative to its root. It may only exist encrypted.
+func CommittedInput(day int) string {
+	return fmt.Sprintf("day%d/day%d_input.txt", day, day)
+}
+
+// InputStore returns the input cache configured by the environment:
+// $AOC_CACHE_DIR (default: the user cache directory), $AOC_BASE_URL (default:
+// the Advent of Code server) and $AOC_SESSION.
+func InputStore() (*cache.Store, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &cache.Store{Dir: dir}
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		store.Client = remote.NewClient(BaseURL(), session)
+	}
+	return store, nil
+}
+
+// CacheDir returns $AOC_CACHE_DIR, or the user cache directory if it is unset.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("AOC_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	return cache.DefaultDir()
+}
+
+// HistoryPath returns where the last run of each day and part is kept.
+func HistoryPath() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.json"), nil
+}
+
+// RecordRuns saves the successful results in the history, as the last run of
+// their day and part.
+func RecordRuns(results ...runner.Result) error {
+	path, err := HistoryPath()
+	if err != nil {
+		return err
+	}
+	runs, err := history.Load(path)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, result := range results {
+		if result.Err == nil {
+			runs.Record(history.Run{Day: result.Day, Part: result.Part, Answer: result.Answer, Duration: result.Duration, Time: now})
+		}
+	}
+	return runs.Save()
+}
+
+// BaseURL returns $AOC_BASE_URL, or the Advent of Code server if it is unset.
+func BaseURL() string {
+	if baseURL := os.Getenv("AOC_BASE_URL"); baseURL != "" {
+		return baseURL
+	}
+	return remote.DefaultBaseURL
+}
+
+// ReadInput reads the whole input for a day, opened as by OpenInput.
+func ReadInput(day int, path string) ([]byte, error) {
+	reader, err := OpenInput(day, path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// The output formats of -format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// FormatUsage describes -format.
+const FormatUsage = "output format: text, or json for one JSON object per result"
+
+// CheckFormat returns an error for an unknown output format.
+func CheckFormat(format string) error {
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf("unknown format %q: want %s or %s", format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// RunPart solves one day and part against its input and prints the answer,
+// or the whole Result as JSON. It returns the Result's error. A run on the
+// day's default input is recorded in the history.
+func RunPart(format string, puzzle solver.Puzzle, part int, inputPath string) error {
+	var result runner.Result
+	input, err := ReadInput(puzzle.Day, inputPath)
+	if err != nil {
+		result = runner.Result{Day: puzzle.Day, Part: part, Err: err}
+	} else {
+		result = runner.Run(context.Background(), puzzle, part, input, 0)
+	}
+
+	if inputPath == "" && result.Err == nil {
+		if err := RecordRuns(result); err != nil {
+			return err
+		}
+	}
+
+	if format == FormatJSON {
+		if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+			return err
+		}
+	} else if result.Err == nil {
+		fmt.Println(result.Answer)
+	}
+	return result.Err
+}
+
+// DayMain is the main function of a single day's command. The part comes from
+// -part, defaultPart without it, or, for the commands that took a word
+// instead, a positional argument looked up in partNames.
+func DayMain(day int, s solver.Solver, defaultPart int, partNames map[string]int) {
+	part := flag.Int("part", defaultPart, "the puzzle part to run (1 or 2)")
+	inputPath := flag.String("input", "", "the input file, or - for stdin (default: the cached input)")
+	format := flag.String("format", FormatText, FormatUsage)
+	flag.Parse()
+
+	if err := CheckFormat(*format); err != nil {
+		fmt.Fprintf(os.Stderr, "day%d: %v\n", day, err)
+		os.Exit(2)
+	}
+
+	if flag.NArg() > 0 {
+		named, ok := partNames[flag.Arg(0)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "day%d: unknown argument %q\n", day, flag.Arg(0))
+			os.Exit(2)
+		}
+		*part = named
+	}
+
+	if err := RunPart(*format, solver.Puzzle{Day: day, Solver: s}, *part, *inputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "day%d: %v\n", day, err)
+		os.Exit(1)
+	}
+}
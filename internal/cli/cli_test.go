@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenInputFetches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2023/day/6/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("Time:      7  15   30\nDistance:  9  40  200\n"))
+	}))
+	defer server.Close()
+
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	t.Setenv("AOC_BASE_URL", server.URL)
+	t.Setenv("AOC_SESSION", "secret")
+
+	reader, err := OpenInput(6, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "Time:      7  15   30\nDistance:  9  40  200\n"
+	if string(got) != expected {
+		t.Fatalf("Expected %q, but got %q", expected, got)
+	}
+}
+
+func TestOpenInputNotCached(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	t.Setenv("AOC_SESSION", "")
+
+	if _, err := OpenInput(6, ""); err == nil {
+		t.Fatalf("Expected an error without a cached input or a session")
+	}
+}
@@ -0,0 +1,106 @@
+// Package differential checks a day's solver against its oracle, a brute-force
+// solution too slow for real inputs, on small generated inputs. Where the two
+// disagree, at least one of them is wrong, and the input shows where to look.
+package differential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/inputgen"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Seeds is how many inputs Run checks each part against.
+const Seeds = 200
+
+// Divergence is an input on which a solver and its oracle disagree.
+type Divergence struct {
+	Day    int
+	Part   int
+	Seed   int64
+	Input  string
+	Got    string // The solver's answer, or its error.
+	Oracle string // The oracle's answer, or its error.
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("day %d part %d: seed %d: solver got %s, oracle got %s, for input:\n%s",
+		d.Day, d.Part, d.Seed, d.Got, d.Oracle, d.Input)
+}
+
+// Check solves a part of the puzzle with both its solver and its oracle for
+// the small inputs drawn from seeds 1 to seeds, and returns the first
+// Divergence, or nil if they always agree. It returns solver.ErrUnsolved if
+// either hasn't solved the part.
+func Check(ctx context.Context, puzzle solver.Puzzle, part int, seeds int) (*Divergence, error) {
+	if puzzle.Oracle == nil {
+		return nil, fmt.Errorf("day %d has no oracle", puzzle.Day)
+	}
+	oracle := solver.Puzzle{Day: puzzle.Day, Title: puzzle.Title, Solver: puzzle.Oracle}
+
+	for seed := int64(1); seed <= int64(seeds); seed++ {
+		in, err := inputgen.Small(puzzle.Day, seed)
+		if err != nil {
+			return nil, err
+		}
+
+		got, err := solve(ctx, puzzle, part, in)
+		if err != nil {
+			return nil, err
+		}
+		want, err := solve(ctx, oracle, part, in)
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			return &Divergence{Day: puzzle.Day, Part: part, Seed: seed, Input: in, Got: got, Oracle: want}, nil
+		}
+	}
+	return nil, nil
+}
+
+// solve returns the answer for a part, or the text of its error so that the
+// solver and oracle can be compared on invalid inputs too. It returns an
+// error only for an unsolved part or a done ctx.
+func solve(ctx context.Context, puzzle solver.Puzzle, part int, in string) (string, error) {
+	got, err := puzzle.Solve(ctx, part, strings.NewReader(in))
+	switch {
+	case errors.Is(err, solver.ErrUnsolved):
+		return "", err
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case err != nil:
+		return "error: " + err.Error(), nil
+	}
+	return got, nil
+}
+
+// Run checks each part of the registered solver for day against its oracle
+// as a subtest named partN, and fails with the first Divergence. A part that
+// isn't solved yet is skipped.
+func Run(t *testing.T, day int) {
+	t.Helper()
+
+	puzzle, err := solver.Lookup(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range solver.Parts {
+		t.Run(fmt.Sprintf("part%d", part), func(t *testing.T) {
+			divergence, err := Check(context.Background(), puzzle, part, Seeds)
+			switch {
+			case errors.Is(err, solver.ErrUnsolved):
+				t.Skip(err)
+			case err != nil:
+				t.Fatal(err)
+			case divergence != nil:
+				t.Fatal(divergence)
+			}
+		})
+	}
+}
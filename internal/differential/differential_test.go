@@ -0,0 +1,54 @@
+package differential
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// lineCounter counts the lines of an input, and stops counting at limit.
+type lineCounter struct{ limit int }
+
+func (c lineCounter) Part1(ctx context.Context, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(min(strings.Count(string(data), "\n"), c.limit), nil)
+}
+
+func (lineCounter) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return "", solver.ErrUnsolved
+}
+
+func TestCheck(t *testing.T) {
+	puzzle := solver.Puzzle{Day: 1, Solver: lineCounter{limit: 5}, Oracle: lineCounter{limit: 100}}
+
+	divergence, err := Check(context.Background(), puzzle, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if divergence == nil {
+		t.Fatalf("Expected a divergence on an input of more than 5 lines")
+	}
+	if divergence.Got != "5" || strings.Count(divergence.Input, "\n") <= 5 {
+		t.Fatalf("Expected a divergence on more than 5 lines, but got %v", divergence)
+	}
+
+	puzzle.Solver = lineCounter{limit: 100}
+	if divergence, err := Check(context.Background(), puzzle, 1, 100); err != nil || divergence != nil {
+		t.Fatalf("Expected no divergence, but got %v, %v", divergence, err)
+	}
+
+	if _, err := Check(context.Background(), puzzle, 2, 100); err != solver.ErrUnsolved {
+		t.Fatalf("Expected %v, but got %v", solver.ErrUnsolved, err)
+	}
+
+	puzzle.Oracle = nil
+	if _, err := Check(context.Background(), puzzle, 1, 100); err == nil {
+		t.Fatalf("Expected an error for a day without an oracle")
+	}
+}
@@ -0,0 +1,125 @@
+// Package examples extracts the example inputs and their answers from a saved
+// puzzle page and writes them as testdata files:
+//
+//	example1.txt        the first example input
+//	example1.part1.want the part 1 answer for it
+//	example2.txt        a second example, if part 2 brings its own
+//	example2.part2.want the part 2 answer for it
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Example is one example input and the answers the puzzle gives for it.
+type Example struct {
+	Input string
+	Wants map[int]string // Keyed by part.
+}
+
+var (
+	articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	preRegex     = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerRegex  = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagRegex     = regexp.MustCompile(`<[^>]+>`)
+)
+
+// Extract reads the examples from a puzzle page. Each part is described in
+// its own <article>. The part's example is the first <pre><code> block in its
+// article, or the first example when it has none, and its answer is the last
+// emphasized code in the article. Examples are returned in the order they
+// appear.
+func Extract(page string) ([]Example, error) {
+	articles := articleRegex.FindAllStringSubmatch(page, -1)
+	if len(articles) == 0 {
+		return nil, errors.New("no puzzle description found")
+	}
+
+	var examples []Example
+	for i, article := range articles {
+		part := i + 1
+
+		var answer string
+		answers := answerRegex.FindAllStringSubmatch(article[1], -1)
+		if len(answers) > 0 {
+			last := answers[len(answers)-1]
+			answer = text(last[1] + last[2])
+		}
+
+		index := 0
+		if pre := preRegex.FindStringSubmatch(article[1]); pre != nil {
+			input := text(pre[1])
+			if !strings.HasSuffix(input, "\n") {
+				input += "\n"
+			}
+			index = -1
+			for j, example := range examples {
+				if example.Input == input {
+					index = j
+				}
+			}
+			if index < 0 {
+				examples = append(examples, Example{Input: input, Wants: map[int]string{}})
+				index = len(examples) - 1
+			}
+		}
+		if len(examples) == 0 {
+			return nil, fmt.Errorf("part %d: no example input found", part)
+		}
+
+		if answer != "" {
+			examples[index].Wants[part] = answer
+		}
+	}
+
+	return examples, nil
+}
+
+// Write writes the examples to dir. It returns the paths written, and fails
+// before writing anything if a file exists unless overwrite is set.
+func Write(dir string, examples []Example, overwrite bool) ([]string, error) {
+	files := map[string]string{}
+	var paths []string
+	add := func(name string, content string) {
+		path := filepath.Join(dir, name)
+		files[path] = content
+		paths = append(paths, path)
+	}
+	for i, example := range examples {
+		add(fmt.Sprintf("example%d.txt", i+1), example.Input)
+		for part := 1; part <= 2; part++ {
+			if want, ok := example.Wants[part]; ok {
+				add(fmt.Sprintf("example%d.part%d.want", i+1, part), want+"\n")
+			}
+		}
+	}
+
+	if !overwrite {
+		for _, path := range paths {
+			if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("%s already exists", path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte(files[path]), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// text returns the text of an HTML fragment.
+func text(fragment string) string {
+	return html.UnescapeString(tagRegex.ReplaceAllString(fragment, ""))
+}
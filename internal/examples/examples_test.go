@@ -0,0 +1,103 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	page, err := os.ReadFile("testdata/day6.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Extract(string(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Example{
+		{
+			Input: "Time:      7  15   30\nDistance:  9  40  200\n",
+			Wants: map[int]string{1: "288", 2: "71503"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %q, but got %q", expected, got)
+	}
+}
+
+func TestExtractNewExample(t *testing.T) {
+	page := `<article class="day-desc"><pre><code>1abc2
+treb7uchet</code></pre><p>Adding these together produces <code><em>142</em></code>.</p></article>
+<article class="day-desc"><pre><code>two1nine
+<em>7</em>pqrstsixteen
+</code></pre><p>Adding these together produces <code><em>281</em></code>.</p></article>`
+
+	got, err := Extract(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Example{
+		{Input: "1abc2\ntreb7uchet\n", Wants: map[int]string{1: "142"}},
+		{Input: "two1nine\n7pqrstsixteen\n", Wants: map[int]string{2: "281"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %q, but got %q", expected, got)
+	}
+}
+
+func TestExtractErrors(t *testing.T) {
+	testCases := []string{
+		"<html><body>Puzzle inputs differ by user.</body></html>",
+		`<article class="day-desc"><p>No example here, the answer is <code><em>1</em></code>.</p></article>`,
+	}
+
+	for _, page := range testCases {
+		if _, err := Extract(page); err == nil {
+			t.Fatalf("Expected an error for %q", page)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata")
+	examples := []Example{
+		{Input: "1abc2\n", Wants: map[int]string{1: "12"}},
+		{Input: "two1nine\n", Wants: map[int]string{2: "29"}},
+	}
+
+	paths, err := Write(dir, examples, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"example1.txt":        "1abc2\n",
+		"example1.part1.want": "12\n",
+		"example2.txt":        "two1nine\n",
+		"example2.part2.want": "29\n",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %d files, but got %v", len(expected), paths)
+	}
+	for name, content := range expected {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Fatalf("%s: expected %q, but got %q", name, content, got)
+		}
+	}
+
+	if _, err := Write(dir, examples, false); err == nil {
+		t.Fatalf("Expected an error overwriting examples")
+	}
+	if _, err := Write(dir, examples, true); err != nil {
+		t.Fatal(err)
+	}
+}
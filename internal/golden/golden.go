@@ -0,0 +1,156 @@
+// Package golden runs a day's solver against the cases in its testdata
+// directory. A case is an input, NAME.txt, with the answer expected for each
+// part in NAME.part1.want and NAME.part2.want, or the error expected in
+// NAME.part1.err and NAME.part2.err. Adding a case is a matter of adding its
+// files.
+package golden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Dir is where cases are kept, relative to the day's package.
+const Dir = "testdata"
+
+// Case is an input in a testdata directory and what is expected of it for
+// one part: an answer, Want, or an error, WantErr. An input with nothing
+// expected of it is a Case with Part 0.
+type Case struct {
+	Name    string // The input's file name without .txt.
+	Path    string
+	Part    int
+	Want    string
+	WantErr string
+}
+
+// Cases returns the cases in dir, ordered by name and part.
+func Cases(dir string) ([]Case, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(inputs)
+
+	var cases []Case
+	for _, path := range inputs {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		found := false
+		for _, part := range solver.Parts {
+			want, wantErr, ok, err := expected(dir, name, part)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				cases = append(cases, Case{Name: name, Path: path, Part: part, Want: want, WantErr: wantErr})
+				found = true
+			}
+		}
+		if !found {
+			cases = append(cases, Case{Name: name, Path: path})
+		}
+	}
+	return cases, nil
+}
+
+// Check solves the case with puzzle and returns an error describing how the
+// result differs from what is expected, or solver.ErrUnsolved if the part
+// isn't solved yet.
+func (c Case) Check(ctx context.Context, puzzle solver.Puzzle) error {
+	file, err := os.Open(c.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	got, err := puzzle.Solve(ctx, c.Part, file)
+	switch {
+	case errors.Is(err, solver.ErrUnsolved):
+		return err
+	case c.WantErr != "":
+		if err == nil || err.Error() != c.WantErr {
+			return fmt.Errorf("Expected error %q, but got %v", c.WantErr, err)
+		}
+	case err != nil:
+		return err
+	case got != c.Want:
+		return fmt.Errorf("Expected %s, but got %s", c.Want, got)
+	}
+	return nil
+}
+
+// Run runs every case in Dir against the registered solver for day, each part
+// as a subtest named NAME/partN. A part that isn't solved yet, and an input
+// with nothing expected of it, is skipped.
+func Run(t *testing.T, day int) {
+	t.Helper()
+
+	puzzle, err := solver.Lookup(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := Cases(Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("no cases in %s", Dir)
+	}
+
+	for _, c := range cases {
+		c := c
+		if c.Part == 0 {
+			t.Run(c.Name, func(t *testing.T) {
+				t.Skipf("%s has no .want or .err files", c.Path)
+			})
+			continue
+		}
+
+		t.Run(fmt.Sprintf("%s/part%d", c.Name, c.Part), func(t *testing.T) {
+			err := c.Check(context.Background(), puzzle)
+			if errors.Is(err, solver.ErrUnsolved) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// expected reads what a case expects for a part: an answer or an error.
+func expected(dir string, name string, part int) (want string, wantErr string, ok bool, err error) {
+	base := filepath.Join(dir, fmt.Sprintf("%s.part%d", name, part))
+	if data, err := os.ReadFile(base + ".want"); err == nil {
+		return strings.TrimSpace(string(data)), "", true, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", "", false, err
+	}
+	if data, err := os.ReadFile(base + ".err"); err == nil {
+		return "", strings.TrimSpace(string(data)), true, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", "", false, err
+	}
+	return "", "", false, nil
+}
+
+// Input returns the contents of a file in Dir, for tests that need an example
+// to build on.
+func Input(t testing.TB, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(Dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,70 @@
+package golden
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// testSolver counts the lines of its input and fails part 2.
+type testSolver struct{}
+
+func (testSolver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return strconv.Itoa(strings.Count(string(data), "\n")), err
+}
+
+func (testSolver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return "", errors.New("no part 2")
+}
+
+func init() {
+	solver.Register(98, "Golden", testSolver{})
+}
+
+func TestRun(t *testing.T) {
+	Run(t, 98)
+}
+
+func TestInput(t *testing.T) {
+	if got := Input(t, "lines.txt"); got != "a\nb\n" {
+		t.Fatalf("Expected %q, but got %q", "a\nb\n", got)
+	}
+}
+
+func TestCases(t *testing.T) {
+	cases, err := Cases(Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Case{
+		{Name: "lines", Path: "testdata/lines.txt", Part: 1, Want: "2"},
+		{Name: "lines", Path: "testdata/lines.txt", Part: 2, WantErr: "no part 2"},
+	}
+	if !reflect.DeepEqual(cases, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, cases)
+	}
+
+	puzzle, err := solver.Lookup(98)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		if err := c.Check(context.Background(), puzzle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wrong := expected[0]
+	wrong.Want = "3"
+	if err := wrong.Check(context.Background(), puzzle); err == nil {
+		t.Fatalf("Expected an error for a wrong answer")
+	}
+}
@@ -0,0 +1,166 @@
+// Package runner runs many days and parts at once on a bounded pool of
+// workers, each with its own deadline.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Result is the outcome of running one day and part.
+type Result struct {
+	Day         int
+	Part        int
+	Answer      string
+	Duration    time.Duration
+	InputSHA256 string
+	Err         error
+}
+
+// jsonResult is the JSON encoding of a Result.
+type jsonResult struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	Answer      string `json:"answer"`
+	DurationNS  int64  `json:"duration_ns"`
+	InputSHA256 string `json:"input_sha256"`
+	Error       string `json:"error"`
+}
+
+// MarshalJSON encodes a Result as
+// {day, part, answer, duration_ns, input_sha256, error}, with an empty error
+// when there is none.
+func (r Result) MarshalJSON() ([]byte, error) {
+	errText := ""
+	if r.Err != nil {
+		errText = r.Err.Error()
+	}
+	return json.Marshal(jsonResult{r.Day, r.Part, r.Answer, r.Duration.Nanoseconds(), r.InputSHA256, errText})
+}
+
+// UnmarshalJSON decodes a Result encoded by MarshalJSON, such as one printed
+// by a day's command with -format json. The error keeps only its text.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var decoded jsonResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*r = Result{
+		Day:         decoded.Day,
+		Part:        decoded.Part,
+		Answer:      decoded.Answer,
+		Duration:    time.Duration(decoded.DurationNS),
+		InputSHA256: decoded.InputSHA256,
+	}
+	if decoded.Error != "" {
+		r.Err = errors.New(decoded.Error)
+	}
+	return nil
+}
+
+// Options configure RunAll.
+type Options struct {
+	Workers int           // How many parts run at once. At least one is used.
+	Timeout time.Duration // How long each part may run. Zero means no limit.
+}
+
+type job struct {
+	puzzle solver.Puzzle
+	part   int
+	input  []byte
+}
+
+// RunAll runs every part of every puzzle against the input returned by
+// readInput and returns the results ordered by day and part. A part that
+// outlives its deadline is reported with context.DeadlineExceeded as soon as
+// the deadline passes, whether or not its solver notices.
+func RunAll(ctx context.Context, puzzles []solver.Puzzle, readInput func(day int) ([]byte, error), opts Options) []Result {
+	var results []Result
+	var jobs []job
+	for _, puzzle := range puzzles {
+		input, err := readInput(puzzle.Day)
+		for _, part := range solver.Parts {
+			if err != nil {
+				results = append(results, Result{Day: puzzle.Day, Part: part, Err: err})
+				continue
+			}
+			jobs = append(jobs, job{puzzle, part, input})
+		}
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	queue := make(chan job)
+	done := make(chan Result)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				done <- Run(ctx, j.puzzle, j.part, j.input, opts.Timeout)
+			}
+		}()
+	}
+
+	go func() {
+		for _, j := range jobs {
+			queue <- j
+		}
+		close(queue)
+		wg.Wait()
+		close(done)
+	}()
+
+	for result := range done {
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].Part < results[j].Part
+	})
+	return results
+}
+
+// Run runs one part of a puzzle against an input, giving up on it once the
+// timeout passes or ctx is done. A solver that ignores its context keeps
+// running in the background until it returns.
+func Run(ctx context.Context, puzzle solver.Puzzle, part int, input []byte, timeout time.Duration) Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	hash := answers.Hash(input)
+	result := Result{Day: puzzle.Day, Part: part, InputSHA256: hash}
+	solved := make(chan struct{})
+	start := time.Now()
+	go func() {
+		defer close(solved)
+		result.Answer, result.Err = puzzle.Solve(ctx, part, bytes.NewReader(input))
+	}()
+
+	select {
+	case <-solved:
+		result.Duration = time.Since(start)
+		return result
+	case <-ctx.Done():
+		return Result{Day: puzzle.Day, Part: part, Duration: time.Since(start), InputSHA256: hash, Err: ctx.Err()}
+	}
+}
@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// testSolver answers with its input after sleeping for delay. A negative delay
+// blocks until release is closed, ignoring ctx.
+type testSolver struct {
+	delay   time.Duration
+	release chan struct{}
+	running *int32
+	maxSeen *int32
+}
+
+func (s testSolver) solve(r io.Reader) (string, error) {
+	if s.running != nil {
+		n := atomic.AddInt32(s.running, 1)
+		defer atomic.AddInt32(s.running, -1)
+		for {
+			max := atomic.LoadInt32(s.maxSeen)
+			if n <= max || atomic.CompareAndSwapInt32(s.maxSeen, max, n) {
+				break
+			}
+		}
+	}
+
+	if s.delay < 0 {
+		<-s.release
+	} else {
+		time.Sleep(s.delay)
+	}
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func (s testSolver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return s.solve(r)
+}
+
+func (s testSolver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return "", solver.ErrUnsolved
+}
+
+func TestRunAll(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	puzzles := []solver.Puzzle{
+		{Day: 3, Solver: testSolver{delay: -1, release: release}},
+		{Day: 1, Solver: testSolver{}},
+		{Day: 2, Solver: testSolver{}},
+	}
+	errMissing := errors.New("missing")
+	readInput := func(day int) ([]byte, error) {
+		if day == 2 {
+			return nil, errMissing
+		}
+		return []byte{'0' + byte(day)}, nil
+	}
+
+	results := RunAll(context.Background(), puzzles, readInput, Options{Workers: 2, Timeout: 50 * time.Millisecond})
+
+	expected := []struct {
+		day    int
+		part   int
+		answer string
+		err    error
+	}{
+		{1, 1, "1", nil},
+		{1, 2, "", solver.ErrUnsolved},
+		{2, 1, "", errMissing},
+		{2, 2, "", errMissing},
+		{3, 1, "", context.DeadlineExceeded},
+		{3, 2, "", solver.ErrUnsolved},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, but got %d", len(expected), len(results))
+	}
+	for i, tc := range expected {
+		got := results[i]
+		if got.Day != tc.day || got.Part != tc.part || got.Answer != tc.answer || !errors.Is(got.Err, tc.err) {
+			t.Fatalf("Expected %+v, but got %+v", tc, got)
+		}
+	}
+}
+
+func TestRunAllWorkers(t *testing.T) {
+	var running, maxSeen int32
+	var puzzles []solver.Puzzle
+	for day := 1; day <= 8; day++ {
+		puzzles = append(puzzles, solver.Puzzle{Day: day, Solver: testSolver{delay: 10 * time.Millisecond, running: &running, maxSeen: &maxSeen}})
+	}
+	readInput := func(day int) ([]byte, error) { return nil, nil }
+
+	RunAll(context.Background(), puzzles, readInput, Options{Workers: 3})
+
+	if maxSeen != 3 {
+		t.Fatalf("Expected %d solvers at once, but got %d", 3, maxSeen)
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	testCases := []struct {
+		result   Result
+		expected string
+	}{
+		{
+			Result{Day: 6, Part: 1, Answer: "288", Duration: 1500, InputSHA256: "abc"},
+			`{"day":6,"part":1,"answer":"288","duration_ns":1500,"input_sha256":"abc","error":""}`,
+		},
+		{
+			Result{Day: 7, Part: 2, Duration: time.Second, InputSHA256: "def", Err: context.DeadlineExceeded},
+			`{"day":7,"part":2,"answer":"","duration_ns":1000000000,"input_sha256":"def","error":"context deadline exceeded"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := json.Marshal(tc.result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.expected {
+			t.Fatalf("Expected %s, but got %s", tc.expected, got)
+		}
+
+		var decoded Result
+		if err := json.Unmarshal(got, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(decoded) != fmt.Sprint(tc.result) {
+			t.Fatalf("Expected %v, but got %v", tc.result, decoded)
+		}
+	}
+}
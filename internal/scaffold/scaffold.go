@@ -0,0 +1,122 @@
+// Package scaffold creates the files for a new day: its package, a test that
+// runs its testdata cases, its example, its command, and its import in package
+// days.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Day describes the day to create.
+type Day struct {
+	Day     int
+	Title   string
+	Example []byte // The example input. May be empty.
+}
+
+const modulePath = "github.com/scottbarnes/advent-of-code-2023"
+
+var importRegex = regexp.MustCompile(`(?s)import \((.*?)\)`)
+
+// Create writes the files for a day under root, the root of the repository,
+// and returns their paths. It fails without writing anything if the day
+// already exists.
+func Create(root string, d Day) ([]string, error) {
+	if d.Day < 1 || d.Day > 25 {
+		return nil, fmt.Errorf("day %d is not between 1 and 25", d.Day)
+	}
+	if d.Title == "" {
+		d.Title = fmt.Sprintf("Day %d", d.Day)
+	}
+
+	pkg := fmt.Sprintf("day%d", d.Day)
+	for _, dir := range []string{pkg, filepath.Join("cmd", pkg)} {
+		if _, err := os.Stat(filepath.Join(root, dir)); !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s already exists", dir)
+		}
+	}
+
+	daysPath := filepath.Join(root, "days", "days.go")
+	days, err := os.ReadFile(daysPath)
+	if err != nil {
+		return nil, err
+	}
+	days, err = addImport(days, modulePath+"/"+pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		path     string
+		template *template.Template
+	}{
+		{filepath.Join(pkg, pkg+".go"), dayTemplate},
+		{filepath.Join(pkg, pkg+"_test.go"), testTemplate},
+		{filepath.Join("cmd", pkg, "main.go"), mainTemplate},
+	}
+	contents := map[string][]byte{
+		filepath.Join(pkg, "testdata", "example1.txt"): d.Example,
+		filepath.Join("days", "days.go"):               days,
+	}
+	paths := []string{}
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := f.template.Execute(&buf, d); err != nil {
+			return nil, err
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.path, err)
+		}
+		contents[f.path] = source
+		paths = append(paths, f.path)
+	}
+	paths = append(paths, filepath.Join(pkg, "testdata", "example1.txt"), filepath.Join("days", "days.go"))
+
+	for _, path := range paths {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(full, contents[path], 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// addImport adds a blank import of pkg to the source of package days. The
+// imports end up in gofmt's order.
+func addImport(source []byte, pkg string) ([]byte, error) {
+	match := importRegex.FindSubmatchIndex(source)
+	if match == nil {
+		return nil, errors.New("days/days.go: no import block")
+	}
+
+	imports := []string{}
+	for _, line := range strings.Split(string(source[match[2]:match[3]]), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			imports = append(imports, line)
+		}
+	}
+	imports = append(imports, fmt.Sprintf("_ %q", pkg))
+
+	var buf bytes.Buffer
+	buf.Write(source[:match[2]])
+	buf.WriteString("\n")
+	for _, line := range imports {
+		buf.WriteString("\t" + line + "\n")
+	}
+	buf.Write(source[match[3]:])
+
+	return format.Source(buf.Bytes())
+}
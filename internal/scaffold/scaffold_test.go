@@ -0,0 +1,67 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const daysSource = `// Package days imports every day.
+package days
+
+import (
+	_ "github.com/scottbarnes/advent-of-code-2023/day1"
+	_ "github.com/scottbarnes/advent-of-code-2023/day11"
+	_ "github.com/scottbarnes/advent-of-code-2023/day2"
+	_ "github.com/scottbarnes/advent-of-code-2023/day9"
+)
+`
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "days"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(daysSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := Create(root, Day{Day: 10, Title: "Pipe Maze", Example: []byte("-L|F7\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"day10/day10.go",
+		"day10/day10_test.go",
+		"cmd/day10/main.go",
+		"day10/testdata/example1.txt",
+		"days/days.go",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, paths)
+	}
+
+	day, err := os.ReadFile(filepath.Join(root, "day10", "day10.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(day), `solver.Register(10, "Pipe Maze", Solver{})`) {
+		t.Fatalf("Expected day10.go to register day 10, but got\n%s", day)
+	}
+
+	days, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDays := strings.Replace(daysSource, "day1\"\n", "day1\"\n\t_ \"github.com/scottbarnes/advent-of-code-2023/day10\"\n", 1)
+	if string(days) != expectedDays {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expectedDays, days)
+	}
+
+	if _, err := Create(root, Day{Day: 10}); err == nil {
+		t.Fatalf("Expected an error creating a day that exists")
+	}
+}
@@ -0,0 +1,83 @@
+package scaffold
+
+import "text/template"
+
+var dayTemplate = template.Must(template.New("day").Parse(`// Package day{{.Day}} solves {{.Title}}.
+package day{{.Day}}
+
+import (
+	"context"
+	"io"
+
+	"github.com/scottbarnes/advent-of-code-2023/input"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+type puzzlePart int
+
+const (
+	part1 puzzlePart = iota
+	part2
+)
+
+func init() {
+	solver.Register({{.Day}}, {{printf "%q" .Title}}, Solver{})
+}
+
+// Solver solves day {{.Day}}.
+type Solver struct{}
+
+// Part1 solves part 1.
+func (Solver) Part1(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part1))
+}
+
+// Part2 solves part 2.
+func (Solver) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return solver.Int(run(ctx, r, part2))
+}
+
+// parse() turns the lines of the input into the puzzle's data.
+func parse(lines []string) ([]string, error) {
+	return lines, nil
+}
+
+// run() is the entrypoint to the program.
+func run(ctx context.Context, file io.Reader, part puzzlePart) (int, error) {
+	lines, err := input.Lines(file)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := parse(lines); err != nil {
+		return 0, err
+	}
+
+	return 0, solver.ErrUnsolved
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package day{{.Day}}
+
+import (
+	"testing"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+)
+
+func TestExamples(t *testing.T) {
+	golden.Run(t, {{.Day}})
+}
+`))
+
+var mainTemplate = template.Must(template.New("main").Parse(`// Command day{{.Day}} prints the day {{.Day}} answer for -part.
+package main
+
+import (
+	"github.com/scottbarnes/advent-of-code-2023/day{{.Day}}"
+	"github.com/scottbarnes/advent-of-code-2023/internal/cli"
+)
+
+func main() {
+	cli.DayMain({{.Day}}, day{{.Day}}.Solver{}, nil)
+}
+`))
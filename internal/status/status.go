@@ -0,0 +1,184 @@
+// Package status gathers how far along each day of the event is, from the
+// solver registry, each day's examples, the accepted answers and the run
+// history, and draws it as a calendar.
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/history"
+	"github.com/scottbarnes/advent-of-code-2023/internal/golden"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// Days is how many days the event has.
+const Days = 25
+
+// Examples is how a part fares on its day's examples.
+type Examples int
+
+const (
+	NoExamples Examples = iota
+	ExamplesPass
+	ExamplesFail
+)
+
+// Part is the status of one part of a day.
+type Part struct {
+	Solved   bool // The solver doesn't return solver.ErrUnsolved.
+	Examples Examples
+	Accepted bool // An accepted answer is recorded.
+	LastRun  time.Duration
+	Ran      bool // LastRun is set.
+}
+
+// Day is the status of a day. A day without a registered solver has no
+// parts.
+type Day struct {
+	Day   int
+	Title string
+	Parts []Part
+}
+
+// Collect returns the status of every day of the event. The examples of each
+// registered day are run from its testdata directory under root.
+func Collect(ctx context.Context, root string, store *answers.Store, runs *history.History) []Day {
+	days := make([]Day, Days)
+	for i := range days {
+		days[i].Day = i + 1
+
+		puzzle, err := solver.Lookup(i + 1)
+		if err != nil {
+			continue
+		}
+		days[i].Title = puzzle.Title
+
+		cases, err := golden.Cases(filepath.Join(root, fmt.Sprintf("day%d", puzzle.Day), golden.Dir))
+		if err != nil {
+			cases = nil
+		}
+		for _, part := range solver.Parts {
+			status := examples(ctx, puzzle, part, cases)
+			status.Accepted = store.Has(puzzle.Day, part)
+			if run, ok := runs.Last(puzzle.Day, part); ok {
+				status.LastRun, status.Ran = run.Duration, true
+			}
+			days[i].Parts = append(days[i].Parts, status)
+		}
+	}
+	return days
+}
+
+// examples runs the cases of a part to find whether it is solved and passes
+// them. A part with no cases is solved unless it says otherwise for an empty
+// input.
+func examples(ctx context.Context, puzzle solver.Puzzle, part int, cases []golden.Case) Part {
+	status := Part{Solved: true}
+	for _, c := range cases {
+		if c.Part != part {
+			continue
+		}
+		err := c.Check(ctx, puzzle)
+		switch {
+		case errors.Is(err, solver.ErrUnsolved):
+			return Part{}
+		case err != nil:
+			status.Examples = ExamplesFail
+		case status.Examples == NoExamples:
+			status.Examples = ExamplesPass
+		}
+	}
+
+	if status.Examples == NoExamples {
+		_, err := puzzle.Solve(ctx, part, strings.NewReader(""))
+		status.Solved = !errors.Is(err, solver.ErrUnsolved)
+	}
+	return status
+}
+
+// cellWidth is how wide a day is drawn in the calendar.
+const cellWidth = 18
+
+// WriteCalendar draws the days as a calendar five days wide. Each part of a
+// day is a line of marks and the duration of its last run:
+//
+//	Day 5
+//	1 S E A 1.1ms
+//	2 S x . -
+//
+// See Legend for the marks.
+func WriteCalendar(w io.Writer, days []Day) error {
+	for start := 0; start < len(days); start += 5 {
+		week := days[start:min(start+5, len(days))]
+
+		lines := make([]string, 1+len(solver.Parts))
+		for _, day := range week {
+			lines[0] += fmt.Sprintf("%-*s", cellWidth, fmt.Sprintf("Day %d", day.Day))
+			for i := range solver.Parts {
+				cell := "-"
+				if i < len(day.Parts) {
+					cell = partCell(solver.Parts[i], day.Parts[i])
+				}
+				lines[i+1] += fmt.Sprintf("%-*s", cellWidth, cell)
+			}
+		}
+
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, Legend)
+	return err
+}
+
+// Legend explains the marks drawn by WriteCalendar.
+const Legend = `S solved   E examples pass, x examples fail   A accepted answer
+. not yet  - no solver or no run yet, otherwise the last run's duration
+`
+
+// partCell draws one part of a day.
+func partCell(part int, status Part) string {
+	marks := []string{".", ".", "."}
+	if status.Solved {
+		marks[0] = "S"
+	}
+	switch status.Examples {
+	case ExamplesPass:
+		marks[1] = "E"
+	case ExamplesFail:
+		marks[1] = "x"
+	}
+	if status.Accepted {
+		marks[2] = "A"
+	}
+
+	run := "-"
+	if status.Ran {
+		run = round(status.LastRun).String()
+	}
+	return fmt.Sprintf("%d %s %s", part, strings.Join(marks, " "), run)
+}
+
+// round rounds a duration to three significant figures or so.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
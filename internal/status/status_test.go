@@ -0,0 +1,98 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/answers"
+	"github.com/scottbarnes/advent-of-code-2023/history"
+	"github.com/scottbarnes/advent-of-code-2023/solver"
+)
+
+// lineCounter counts the lines of its input and hasn't solved part 2.
+type lineCounter struct{}
+
+func (lineCounter) Part1(ctx context.Context, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return strconv.Itoa(strings.Count(string(data), "\n")), err
+}
+
+func (lineCounter) Part2(ctx context.Context, r io.Reader) (string, error) {
+	return "", solver.ErrUnsolved
+}
+
+func init() {
+	solver.Register(3, "Status", lineCounter{})
+	solver.Register(4, "No Examples", lineCounter{})
+}
+
+func TestCollect(t *testing.T) {
+	root := t.TempDir()
+	testdata := filepath.Join(root, "day3", "testdata")
+	if err := os.MkdirAll(testdata, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"example1.txt":        "a\nb\n",
+		"example1.part1.want": "2\n",
+		"example1.part2.want": "9\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(testdata, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := &answers.Store{}
+	store.Set(3, 1, "abc", "2")
+	runs := &history.History{}
+	runs.Record(history.Run{Day: 3, Part: 1, Answer: "2", Duration: time.Millisecond})
+
+	days := Collect(context.Background(), root, store, runs)
+	if len(days) != Days {
+		t.Fatalf("Expected %d days, but got %d", Days, len(days))
+	}
+
+	expected := []Day{
+		{Day: 2},
+		{Day: 3, Title: "Status", Parts: []Part{
+			{Solved: true, Examples: ExamplesPass, Accepted: true, LastRun: time.Millisecond, Ran: true},
+			{},
+		}},
+		{Day: 4, Title: "No Examples", Parts: []Part{{Solved: true}, {}}},
+	}
+	if !reflect.DeepEqual(days[1:4], expected) {
+		t.Fatalf("Expected %+v, but got %+v", expected, days[1:4])
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	days := []Day{
+		{Day: 1},
+		{Day: 2, Title: "Cube Conundrum", Parts: []Part{
+			{Solved: true, Examples: ExamplesPass, Accepted: true, LastRun: 1234 * time.Microsecond, Ran: true},
+			{Solved: true, Examples: ExamplesFail},
+		}},
+	}
+
+	var b bytes.Buffer
+	if err := WriteCalendar(&b, days); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Day 1             Day 2\n" +
+		"-                 1 S E A 1.23ms\n" +
+		"-                 2 S x . -\n" +
+		"\n" + Legend
+	if b.String() != expected {
+		t.Fatalf("Expected %q, but got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,146 @@
+// Package watch polls files for changes, and reports how a day's answers
+// changed from one run to the next. Polling needs nothing beyond the standard
+// library and works the same on every system and editor, at the cost of
+// noticing a save up to one interval late.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/runner"
+)
+
+// fileState is what a change to a file is told by.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Snapshot is the state of every file watched, by path.
+type Snapshot map[string]fileState
+
+// Scan returns the state of the files at paths. A directory's files are
+// included, recursively; a path that doesn't exist is left out, so that it
+// shows as added once it is created.
+func Scan(paths []string) (Snapshot, error) {
+	snapshot := Snapshot{}
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			snapshot[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// Changed returns the paths added, removed or modified between old and new,
+// sorted.
+func Changed(old Snapshot, new Snapshot) []string {
+	var changed []string
+	for path, state := range new {
+		if oldState, ok := old[path]; !ok || oldState != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range old {
+		if _, ok := new[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Poll scans paths every interval and calls onChange with the paths changed
+// since the last scan, until ctx is done.
+func Poll(ctx context.Context, paths []string, interval time.Duration, onChange func(changed []string)) error {
+	last, err := Scan(paths)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := Scan(paths)
+		if err != nil {
+			return err
+		}
+		if changed := Changed(last, current); len(changed) > 0 {
+			onChange(changed)
+		}
+		last = current
+	}
+}
+
+// WriteResults writes a line per result, comparing its answer and duration
+// with the previous result for the same part, if there is one:
+//
+//	part 1: 35  1.2ms
+//	part 2: 46 (was 45)  3.1ms (+1.4ms)
+func WriteResults(w io.Writer, previous map[int]runner.Result, results []runner.Result) {
+	for _, result := range results {
+		fmt.Fprintf(w, "part %d: ", result.Part)
+		if result.Err != nil {
+			fmt.Fprintf(w, "error: %v\n", result.Err)
+			continue
+		}
+
+		last, ok := previous[result.Part]
+		switch {
+		case !ok || last.Err != nil:
+			fmt.Fprintf(w, "%s  %v\n", result.Answer, round(result.Duration))
+		case last.Answer != result.Answer:
+			fmt.Fprintf(w, "%s (was %s)  %v (%s)\n", result.Answer, last.Answer, round(result.Duration), delta(result.Duration-last.Duration))
+		default:
+			fmt.Fprintf(w, "%s (unchanged)  %v (%s)\n", result.Answer, round(result.Duration), delta(result.Duration-last.Duration))
+		}
+	}
+}
+
+// round rounds a duration to a precision worth reading.
+func round(d time.Duration) time.Duration {
+	switch abs := max(d, -d); {
+	case abs >= time.Second:
+		return d.Round(time.Millisecond)
+	case abs >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
+
+// delta formats the change in a duration with its sign.
+func delta(d time.Duration) string {
+	if d < 0 {
+		return round(d).String()
+	}
+	return "+" + round(d).String()
+}
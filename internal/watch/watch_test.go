@@ -0,0 +1,113 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/internal/runner"
+)
+
+func TestChanged(t *testing.T) {
+	dir := t.TempDir()
+	day := filepath.Join(dir, "day5.go")
+	input := filepath.Join(dir, "testdata", "example1.txt")
+	missing := filepath.Join(dir, "input.txt")
+	if err := os.MkdirAll(filepath.Dir(input), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{day, input} {
+		if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := []string{dir, missing}
+	before, err := Scan(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 2 {
+		t.Fatalf("Expected 2 files, but got %v", before)
+	}
+
+	if err := os.WriteFile(day, []byte("ab"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(missing, []byte("1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := Scan(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{day, missing, input}
+	if got := Changed(before, after); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, got)
+	}
+	if got := Changed(after, after); got != nil {
+		t.Fatalf("Expected no changes, but got %v", got)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day5.go")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	done := make(chan error)
+	go func() {
+		done <- Poll(ctx, []string{path}, 10*time.Millisecond, func(changed []string) {
+			got = changed
+			cancel()
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("package day5"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+	}
+	if !reflect.DeepEqual(got, []string{path}) {
+		t.Fatalf("Expected %v, but got %v", []string{path}, got)
+	}
+}
+
+func TestWriteResults(t *testing.T) {
+	previous := map[int]runner.Result{
+		1: {Part: 1, Answer: "35", Duration: 2 * time.Millisecond},
+		2: {Part: 2, Answer: "45", Duration: 3 * time.Millisecond},
+	}
+	results := []runner.Result{
+		{Part: 1, Answer: "35", Duration: 1234 * time.Microsecond},
+		{Part: 2, Answer: "46", Duration: 4500 * time.Microsecond},
+	}
+
+	var b bytes.Buffer
+	WriteResults(&b, previous, results)
+	expected := "part 1: 35 (unchanged)  1.23ms (-766µs)\npart 2: 46 (was 45)  4.5ms (+1.5ms)\n"
+	if b.String() != expected {
+		t.Fatalf("Expected %q, but got %q", expected, b.String())
+	}
+
+	b.Reset()
+	WriteResults(&b, map[int]runner.Result{}, []runner.Result{{Part: 1, Err: errors.New("day 5: line 1: bad")}, results[0]})
+	expected = "part 1: error: day 5: line 1: bad\npart 1: 35  1.23ms\n"
+	if b.String() != expected {
+		t.Fatalf("Expected %q, but got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,80 @@
+// Package remote talks to an Advent of Code compatible server. The base URL is
+// configurable so that tests can point a Client at a stand-in server.
+package remote
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Advent of Code server.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Year is the event year of every puzzle in this repository.
+const Year = 2023
+
+// userAgent identifies this repository to the server, as its author asks.
+const userAgent = "github.com/scottbarnes/advent-of-code-2023"
+
+// Client makes authenticated requests to a server.
+type Client struct {
+	BaseURL    string
+	Year       int
+	Session    string // The value of the "session" cookie of a logged in user.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for the 2023 event at baseURL.
+func NewClient(baseURL string, session string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Year:       Year,
+		Session:    session,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Input fetches the puzzle input for a day.
+func (c *Client) Input(day int) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", c.Year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+// newRequest creates a request for path with the session cookie set.
+func (c *Client) newRequest(method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
+}
+
+// do sends a request and returns the response body, or an error for anything
+// other than a 200 response.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,38 @@
+package remote
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2023/day/6/input" {
+			http.NotFound(w, r)
+			return
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("Time:      7  15   30\nDistance:  9  40  200\n"))
+	}))
+	defer server.Close()
+
+	got, err := NewClient(server.URL+"/", "secret").Input(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "Time:      7  15   30\nDistance:  9  40  200\n"
+	if string(got) != expected {
+		t.Fatalf("Expected %q, but got %q", expected, got)
+	}
+
+	_, err = NewClient(server.URL, "wrong").Input(6)
+	expectedErr := "GET /2023/day/6/input: 400 Bad Request: Puzzle inputs differ by user.  Please log in to get your puzzle input."
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Expected %q, but got %v", expectedErr, err)
+	}
+}
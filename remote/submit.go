@@ -0,0 +1,133 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is how the server judged a submitted answer.
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	TooHigh
+	TooLow
+	Incorrect     // Wrong, without a hint of which way.
+	TooSoon       // Rejected unchecked because the last answer was too recent.
+	AlreadySolved // Rejected unchecked because the part is already solved.
+)
+
+var outcomeNames = map[Outcome]string{
+	Unknown:       "unknown",
+	Correct:       "correct",
+	TooHigh:       "too high",
+	TooLow:        "too low",
+	Incorrect:     "incorrect",
+	TooSoon:       "too soon",
+	AlreadySolved: "already solved",
+}
+
+func (o Outcome) String() string {
+	return outcomeNames[o]
+}
+
+// MarshalText stores an Outcome by name.
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText reads an Outcome stored by MarshalText.
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for outcome, name := range outcomeNames {
+		if name == string(text) {
+			*o = outcome
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
+
+// Wrong reports whether the answer was checked and found wrong.
+func (o Outcome) Wrong() bool {
+	return o == TooHigh || o == TooLow || o == Incorrect
+}
+
+// Verdict is the server's response to a submitted answer.
+type Verdict struct {
+	Outcome Outcome
+	Wait    time.Duration // How long until another answer may be submitted.
+	Message string        // The response text, without markup.
+}
+
+var (
+	articleRegex  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex      = regexp.MustCompile(`<[^>]+>`)
+	spaceRegex    = regexp.MustCompile(`\s+`)
+	leftRegex     = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	waitMinRegex  = regexp.MustCompile(`(?i)wait (one|\d+) minutes? before trying again`)
+	verdictPhrase = []struct {
+		phrase  string
+		outcome Outcome
+	}{
+		{"That's the right answer", Correct},
+		{"your answer is too high", TooHigh},
+		{"your answer is too low", TooLow},
+		{"That's not the right answer", Incorrect},
+		{"You gave an answer too recently", TooSoon},
+		{"You don't seem to be solving the right level", AlreadySolved},
+	}
+)
+
+// ParseVerdict reads the Verdict from the HTML page returned for an answer.
+func ParseVerdict(page string) Verdict {
+	text := page
+	if match := articleRegex.FindStringSubmatch(page); match != nil {
+		text = match[1]
+	}
+	text = strings.TrimSpace(spaceRegex.ReplaceAllString(tagRegex.ReplaceAllString(text, ""), " "))
+
+	verdict := Verdict{Message: text}
+	for _, vp := range verdictPhrase {
+		if strings.Contains(text, vp.phrase) {
+			verdict.Outcome = vp.outcome
+			break
+		}
+	}
+
+	if match := leftRegex.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		verdict.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := waitMinRegex.FindStringSubmatch(text); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		verdict.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return verdict
+}
+
+// Submit posts an answer for a day and part and returns the server's Verdict.
+func (c *Client) Submit(day int, part int, answer string) (Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", c.Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	return ParseVerdict(string(body)), nil
+}
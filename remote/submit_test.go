@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseVerdict(t *testing.T) {
+	testCases := []struct {
+		page     string
+		expected Verdict
+	}{
+		{
+			`<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations. <a href="/2023/day/5#part2">[Continue to Part Two]</a></p></article></main>`,
+			Verdict{Correct, 0, "That's the right answer! You are one gold star closer to restoring snow operations. [Continue to Part Two]"},
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2023/day/5">[Return to Day 5]</a></p></article>`,
+			Verdict{TooHigh, time.Minute, "That's not the right answer; your answer is too high. If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. [Return to Day 5]"},
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`,
+			Verdict{TooLow, 5 * time.Minute, "That's not the right answer; your answer is too low. Please wait 5 minutes before trying again."},
+		},
+		{
+			`<article><p>That's not the right answer.  If you're stuck, there are some general tips on the <a href="/2023/about">about page</a>.</p></article>`,
+			Verdict{Incorrect, 0, "That's not the right answer. If you're stuck, there are some general tips on the about page."},
+		},
+		{
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 27s left to wait.</p></article>`,
+			Verdict{TooSoon, 4*time.Minute + 27*time.Second, "You gave an answer too recently; you have to wait after submitting an answer before trying again. You have 4m 27s left to wait."},
+		},
+		{
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 37s left to wait.</p></article>`,
+			Verdict{TooSoon, 37 * time.Second, "You gave an answer too recently; you have to wait after submitting an answer before trying again. You have 37s left to wait."},
+		},
+		{
+			`<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`,
+			Verdict{AlreadySolved, 0, "You don't seem to be solving the right level. Did you already complete it?"},
+		},
+		{
+			`<p>Something else</p>`,
+			Verdict{Unknown, 0, "Something else"},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := ParseVerdict(tc.page)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Expected %+v, but got %+v", tc.expected, got)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/5/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "46" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+	}))
+	defer server.Close()
+
+	got, err := NewClient(server.URL, "secret").Submit(5, 2, "46")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Outcome != Correct {
+		t.Fatalf("Expected %v, but got %v", Correct, got.Outcome)
+	}
+}
+
+func TestOutcomeText(t *testing.T) {
+	for outcome := range outcomeNames {
+		text, err := outcome.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Outcome
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if got != outcome {
+			t.Fatalf("Expected %v, but got %v", outcome, got)
+		}
+	}
+}
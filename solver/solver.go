@@ -0,0 +1,100 @@
+// Package solver is a registry of every day's puzzle solutions, so a single
+// command can run any day and part without knowing how each day is invoked.
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// ErrUnsolved is returned by a Solver for a part that has no solution yet.
+var ErrUnsolved = errors.New("part not solved")
+
+// Parts are the parts every puzzle has.
+var Parts = []int{1, 2}
+
+// Solver solves both parts of a day's puzzle. Answers are strings so that
+// puzzles with non-numeric answers fit the same interface. A part that can run
+// for long should stop and return ctx.Err() once ctx is done.
+type Solver interface {
+	Part1(ctx context.Context, r io.Reader) (string, error)
+	Part2(ctx context.Context, r io.Reader) (string, error)
+}
+
+// Puzzle is a registered day. Oracle, if the day has one, solves it the slow,
+// obvious way, for checking Solver against on small inputs.
+type Puzzle struct {
+	Day    int
+	Title  string
+	Solver Solver
+	Oracle Solver
+}
+
+var registry = make(map[int]Puzzle)
+
+// Register adds the Solver for a day. It is meant to be called from a day's
+// init() and panics if the day is already registered.
+func Register(day int, title string, s Solver) {
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("solver: day %d registered twice", day))
+	}
+	registry[day] = Puzzle{Day: day, Title: title, Solver: s}
+}
+
+// RegisterOracle adds the Oracle for a registered day. Like Register, it is
+// meant to be called from a day's init() and panics if the day isn't
+// registered or already has an Oracle.
+func RegisterOracle(day int, s Solver) {
+	puzzle, ok := registry[day]
+	if !ok {
+		panic(fmt.Sprintf("solver: oracle for unregistered day %d", day))
+	}
+	if puzzle.Oracle != nil {
+		panic(fmt.Sprintf("solver: day %d oracle registered twice", day))
+	}
+	puzzle.Oracle = s
+	registry[day] = puzzle
+}
+
+// Lookup returns the registered Puzzle for a day.
+func Lookup(day int) (Puzzle, error) {
+	puzzle, ok := registry[day]
+	if !ok {
+		return Puzzle{}, fmt.Errorf("no solver registered for day %d", day)
+	}
+	return puzzle, nil
+}
+
+// Puzzles returns every registered Puzzle ordered by day.
+func Puzzles() []Puzzle {
+	puzzles := make([]Puzzle, 0, len(registry))
+	for _, puzzle := range registry {
+		puzzles = append(puzzles, puzzle)
+	}
+	sort.Slice(puzzles, func(i, j int) bool { return puzzles[i].Day < puzzles[j].Day })
+	return puzzles
+}
+
+// Solve runs one part of the puzzle against an input.
+func (p Puzzle) Solve(ctx context.Context, part int, r io.Reader) (string, error) {
+	switch part {
+	case 1:
+		return p.Solver.Part1(ctx, r)
+	case 2:
+		return p.Solver.Part2(ctx, r)
+	default:
+		return "", fmt.Errorf("day %d has no part %d", p.Day, part)
+	}
+}
+
+// Int converts an integer result into an answer, passing through any error.
+func Int(result int, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(result), nil
+}
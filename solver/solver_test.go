@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type testSolver struct{}
+
+func (testSolver) Part1(ctx context.Context, r io.Reader) (string, error) { return Int(42, nil) }
+func (testSolver) Part2(ctx context.Context, r io.Reader) (string, error) { return "", ErrUnsolved }
+
+func TestRegisterAndLookup(t *testing.T) {
+	Register(99, "Test Puzzle", testSolver{})
+
+	puzzle, err := Lookup(99)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := puzzle.Solve(context.Background(), 1, bytes.NewBufferString(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "42" {
+		t.Fatalf("Expected %q, but got %q", "42", got)
+	}
+
+	if _, err := puzzle.Solve(context.Background(), 2, bytes.NewBufferString("")); err != ErrUnsolved {
+		t.Fatalf("Expected %v, but got %v", ErrUnsolved, err)
+	}
+
+	if _, err := puzzle.Solve(context.Background(), 3, bytes.NewBufferString("")); err == nil {
+		t.Fatalf("Expected an error for a part that doesn't exist")
+	}
+
+	if _, err := Lookup(98); err == nil {
+		t.Fatalf("Expected an error for an unregistered day")
+	}
+}
+
+func TestPuzzles(t *testing.T) {
+	Register(97, "Another Test Puzzle", testSolver{})
+
+	Register(96, "Yet Another Test Puzzle", testSolver{})
+
+	days := []int{}
+	for _, puzzle := range Puzzles() {
+		if puzzle.Day == 96 || puzzle.Day == 97 {
+			days = append(days, puzzle.Day)
+		}
+	}
+
+	expected := []int{96, 97}
+	if !reflect.DeepEqual(days, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, days)
+	}
+}
+
+func TestRegisterOracle(t *testing.T) {
+	Register(95, "Oracle Test Puzzle", testSolver{})
+	RegisterOracle(95, testSolver{})
+
+	puzzle, err := Lookup(95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if puzzle.Oracle == nil {
+		t.Fatalf("Expected day 95 to have an oracle")
+	}
+
+	for _, day := range []int{95, 94} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected RegisterOracle(%d) to panic", day)
+				}
+			}()
+			RegisterOracle(day, testSolver{})
+		}()
+	}
+}
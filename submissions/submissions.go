@@ -0,0 +1,131 @@
+// Package submissions records the answers submitted for each day and part and
+// what the server made of them, so an answer known to be wrong is never sent
+// twice.
+package submissions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+)
+
+// Attempt is one answer submitted for a day and part.
+type Attempt struct {
+	Day     int            `json:"day"`
+	Part    int            `json:"part"`
+	Answer  string         `json:"answer"`
+	Outcome remote.Outcome `json:"outcome"`
+	Time    time.Time      `json:"time"`
+	Wait    time.Duration  `json:"wait,omitempty"`
+}
+
+// Log is every Attempt, oldest first, saved as JSON at Path.
+type Log struct {
+	Path     string    `json:"-"`
+	Attempts []Attempt `json:"attempts"`
+}
+
+// Load reads the Log at path. A missing file is an empty Log.
+func Load(path string) (*Log, error) {
+	log := &Log{Path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, log); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return log, nil
+}
+
+// Save writes the Log back to its Path.
+func (l *Log) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(l.Path, append(data, '\n'), 0o600)
+}
+
+// Record adds the Verdict for an answer.
+func (l *Log) Record(day int, part int, answer string, verdict remote.Verdict, now time.Time) {
+	l.Attempts = append(l.Attempts, Attempt{
+		Day:     day,
+		Part:    part,
+		Answer:  answer,
+		Outcome: verdict.Outcome,
+		Time:    now,
+		Wait:    verdict.Wait,
+	})
+}
+
+// Check returns an error if submitting answer for a day and part is known to
+// be pointless: the part is already solved, the answer was already rejected,
+// it lies outside a too high or too low bound, or the server asked to wait and
+// the wait isn't over.
+func (l *Log) Check(day int, part int, answer string, now time.Time) error {
+	value, numeric := parseAnswer(answer)
+
+	for _, attempt := range l.Attempts {
+		if attempt.Day != day || attempt.Part != part {
+			continue
+		}
+
+		switch {
+		case attempt.Outcome == remote.Correct:
+			return fmt.Errorf("day %d part %d was already solved with %s", day, part, attempt.Answer)
+		case attempt.Outcome.Wrong() && attempt.Answer == answer:
+			return fmt.Errorf("%s was already rejected as %v", answer, attempt.Outcome)
+		}
+
+		bound, ok := parseAnswer(attempt.Answer)
+		if !numeric || !ok {
+			continue
+		}
+		switch {
+		case attempt.Outcome == remote.TooHigh && value >= bound:
+			return fmt.Errorf("%s is not below %s, which was too high", answer, attempt.Answer)
+		case attempt.Outcome == remote.TooLow && value <= bound:
+			return fmt.Errorf("%s is not above %s, which was too low", answer, attempt.Answer)
+		}
+	}
+
+	if until := l.waitUntil(); now.Before(until) {
+		return fmt.Errorf("the server asked to wait until %s", until.Format(time.TimeOnly))
+	}
+
+	return nil
+}
+
+// waitUntil returns when the server will next accept an answer. The wait
+// applies to every puzzle, not just the one that caused it.
+func (l *Log) waitUntil() time.Time {
+	var until time.Time
+	for _, attempt := range l.Attempts {
+		if end := attempt.Time.Add(attempt.Wait); attempt.Wait > 0 && end.After(until) {
+			until = end
+		}
+	}
+	return until
+}
+
+// parseAnswer returns the answer as a number, if it is one.
+func parseAnswer(answer string) (int64, bool) {
+	value, err := strconv.ParseInt(answer, 10, 64)
+	return value, err == nil
+}
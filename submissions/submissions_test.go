@@ -0,0 +1,75 @@
+package submissions
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scottbarnes/advent-of-code-2023/remote"
+)
+
+var start = time.Date(2023, 12, 5, 6, 0, 0, 0, time.UTC)
+
+func TestCheck(t *testing.T) {
+	log := &Log{}
+	log.Record(5, 2, "100", remote.Verdict{Outcome: remote.TooHigh, Wait: time.Minute}, start)
+	log.Record(5, 2, "20", remote.Verdict{Outcome: remote.TooLow}, start.Add(2*time.Minute))
+	log.Record(5, 2, "50", remote.Verdict{Outcome: remote.Incorrect}, start.Add(4*time.Minute))
+	log.Record(6, 1, "288", remote.Verdict{Outcome: remote.Correct}, start.Add(6*time.Minute))
+
+	later := start.Add(time.Hour)
+	testCases := []struct {
+		day      int
+		part     int
+		answer   string
+		now      time.Time
+		expected bool // Whether submitting is allowed.
+	}{
+		{5, 2, "46", later, true},
+		{5, 2, "50", later, false},  // Already rejected.
+		{5, 2, "100", later, false}, // Already rejected.
+		{5, 2, "101", later, false}, // Above a too high answer.
+		{5, 2, "20", later, false},  // Already rejected.
+		{5, 2, "7", later, false},   // Below a too low answer.
+		{5, 2, "99", later, true},
+		{5, 2, "abc", later, true},                          // Bounds only apply to numbers.
+		{5, 1, "101", later, true},                          // Bounds only apply to their own part.
+		{6, 1, "288", later, false},                         // Already solved.
+		{6, 1, "300", later, false},                         // Already solved.
+		{6, 2, "71503", start.Add(30 * time.Second), false}, // Still waiting.
+		{6, 2, "71503", start.Add(time.Minute), true},
+	}
+
+	for _, tc := range testCases {
+		err := log.Check(tc.day, tc.part, tc.answer, tc.now)
+		if got := err == nil; got != tc.expected {
+			t.Fatalf("day %d part %d answer %s: expected allowed %v, but got %v (%v)", tc.day, tc.part, tc.answer, tc.expected, got, err)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "submissions.json")
+
+	log, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Attempts) != 0 {
+		t.Fatalf("Expected an empty log, but got %v", log.Attempts)
+	}
+
+	log.Record(7, 1, "6440", remote.Verdict{Outcome: remote.TooSoon, Wait: 37 * time.Second}, start)
+	if err := log.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, log) {
+		t.Fatalf("Expected %+v, but got %+v", log, got)
+	}
+}